@@ -3,19 +3,34 @@ package handler
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
 	"encoding/json"
 	"encoding/xml"
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"log/slog"
+	"math"
 	"math/rand"
 	"net/http"
+	"net/url"
 	"os"
+	"os/exec"
+	"path/filepath"
 	"regexp"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
@@ -25,6 +40,7 @@ import (
 	"github.com/joho/godotenv"
 	"github.com/redis/go-redis/v9"
 	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
 )
 
 func init() {
@@ -35,30 +51,83 @@ func init() {
 }
 
 const (
-	baseURL              = "https://huggingface.co/papers"
-	liveURL              = "https://tldr.takara.ai"
-	scrapeTimeout        = 30 * time.Second
-	llmTimeout           = 90 * time.Second
-	maxPapers            = 50
-	cacheKey             = "hf_papers_cache"
-	summaryCacheKey      = "hf_papers_summary_cache"
-	conversationCacheKey = "hf_papers_conversation_cache"
-	podcastCacheKey      = "hf_papers_podcast_cache"
-	cacheDuration        = 24 * time.Hour
+	baseURL                  = "https://huggingface.co/papers"
+	liveURL                  = "https://tldr.takara.ai"
+	scrapeTimeout            = 30 * time.Second
+	llmTimeout               = 90 * time.Second
+	summaryTimeout           = 50 * time.Second // the whole map-reduce, under vercel.json's 60s maxDuration
+	maxPapers                = 50
+	defaultScrapeConcurrency = 8
+	defaultLLMBaseURL        = "https://router.huggingface.co/together/v1"
+	defaultLLMModel          = "Qwen/Qwen2.5-72B-Instruct-Turbo"
+	llmBreakerThreshold      = 3
+	llmBreakerCooldown       = 5 * time.Minute
+	tldrCacheKey             = "hf_papers_tldr"
+	tldrFeedCacheKey         = "hf_papers_tldr_feed"
+	tldrConcurrency          = 4
+	tldrTimeout              = 20 * time.Second
+	summaryPromptTokens      = 400 // estimated size of the briefing instructions
+	minSummaryBudget         = 1024
+	summaryMapConcurrency    = 4
+	maxCondenseRounds        = 3
+	maxSummaryWords          = 200
+	maxSummaryAttempts       = 3
+	maxCondenseAttempts      = 2
+	condenseFallbackWords    = 40
+	defaultTTSConcurrency    = 4
+	ttsTimeout               = 60 * time.Second
+	ttsMaxAttempts           = 3
+	podcastManifestKey       = "podcast-manifest.json"
+	archiveBlobPrefix        = "archive/"
+	podcastLatestKey         = "podcast-latest.mp3"
+	manifestMaxAttempts      = 5
+	podcastBitrate           = 128000 // assumed MP3 bitrate when audio can't be parsed
+	podcastTitle             = "Takara TLDR: Daily Papers Podcast"
+	podcastAuthor            = "Takara.ai"
+	maxArtworkSize           = 5 << 20
+	defaultPresignExpiry     = time.Hour
+	maxPresignExpiry         = 7 * 24 * time.Hour // SigV4 limit
+	maxPodcastEpisodes       = 100
+	rollupConcurrency        = 3
+	rollupTopPapers          = 25
+	cacheKey                 = "hf_papers_cache"
+	summaryCacheKey          = "hf_papers_summary_cache"
+	conversationCacheKey     = "hf_papers_conversation_cache"
+	podcastCacheKey          = "hf_papers_podcast_cache"
+	cacheDuration            = 24 * time.Hour
+	cacheHardTTLFactor       = 2
+	cacheRefreshTimeout      = 50 * time.Second // under the 60s maxDuration in vercel.json
+	archiveKeyPrefix         = "hf_papers_archive:"
+	historyCacheDuration     = 30 * 24 * time.Hour
+	dateLayout               = "2006-01-02"
 )
 
 type Paper struct {
-	Title    string
-	URL      string
-	Abstract string
-	PubDate  time.Time
+	Title     string
+	URL       string
+	Abstract  string
+	PubDate   time.Time
+	Authors   []string
+	Upvotes   int
+	ArxivID   string
+	Thumbnail string
+	GitHubURL string
+	Comments  int
+	// Optional LLM-generated digest, see addTLDRs
+	TLDR         string `json:",omitempty"`
+	WhyItMatters string `json:",omitempty"`
 }
 
 type RSS struct {
-	XMLName xml.Name `xml:"rss"`
-	Version string   `xml:"version,attr"`
-	Channel Channel  `xml:"channel"`
-	XMLNS   string   `xml:"xmlns:atom,attr"`
+	XMLName    xml.Name `xml:"rss"`
+	Version    string   `xml:"version,attr"`
+	Channel    Channel  `xml:"channel"`
+	XMLNS      string   `xml:"xmlns:atom,attr"`
+	XMLNSMedia string   `xml:"xmlns:media,attr,omitempty"`
+	XMLNSDC    string   `xml:"xmlns:dc,attr,omitempty"`
+	// Podcast feeds only
+	XMLNSItunes  string `xml:"xmlns:itunes,attr,omitempty"`
+	XMLNSPodcast string `xml:"xmlns:podcast,attr,omitempty"`
 }
 
 type Channel struct {
@@ -67,7 +136,50 @@ type Channel struct {
 	Description   string   `xml:"description"`
 	LastBuildDate string   `xml:"lastBuildDate"`
 	AtomLink      AtomLink `xml:"atom:link"`
-	Items         []Item   `xml:"item"`
+	Generator     string   `xml:"generator,omitempty"`
+	// Podcast feeds only
+	Language       string          `xml:"language,omitempty"`
+	ItunesAuthor   string          `xml:"itunes:author,omitempty"`
+	ItunesImage    *ItunesImage    `xml:"itunes:image,omitempty"`
+	ItunesCategory *ItunesCategory `xml:"itunes:category,omitempty"`
+	ItunesExplicit string          `xml:"itunes:explicit,omitempty"`
+	ItunesType     string          `xml:"itunes:type,omitempty"`
+	Items          []Item          `xml:"item"`
+}
+
+// UnmarshalXML tells <link> and atom:link apart by namespace. Decoded by tag alone,
+// the empty atom:link would overwrite the channel link and never fill AtomLink.
+func (c *Channel) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type channel Channel
+	var aux struct {
+		channel
+		Links []struct {
+			XMLName xml.Name
+			AtomLink
+			Text string `xml:",chardata"`
+		} `xml:"link"`
+	}
+	if err := d.DecodeElement(&aux, &start); err != nil {
+		return err
+	}
+	*c = Channel(aux.channel)
+	for _, link := range aux.Links {
+		switch link.XMLName.Space {
+		case "http://www.w3.org/2005/Atom":
+			c.AtomLink = link.AtomLink
+		case "":
+			c.Link = strings.TrimSpace(link.Text)
+		}
+	}
+	return nil
+}
+
+type ItunesImage struct {
+	Href string `xml:"href,attr"`
+}
+
+type ItunesCategory struct {
+	Text string `xml:"text,attr"`
 }
 
 type AtomLink struct {
@@ -77,11 +189,84 @@ type AtomLink struct {
 }
 
 type Item struct {
-	Title       string `xml:"title"`
-	Link        string `xml:"link"`
-	Description CDATA  `xml:"description"`
-	PubDate     string `xml:"pubDate"`
-	GUID        GUID   `xml:"guid"`
+	Title       string          `xml:"title"`
+	Link        string          `xml:"link"`
+	Description CDATA           `xml:"description"`
+	Creator     string          `xml:"dc:creator,omitempty"` // author names, as <author> must be an email
+	Categories  []Category      `xml:"category"`
+	Comments    string          `xml:"comments,omitempty"`
+	Thumbnail   *MediaThumbnail `xml:"media:thumbnail,omitempty"`
+	PubDate     string          `xml:"pubDate"`
+	GUID        GUID            `xml:"guid"`
+	// Podcast feeds only
+	Enclosure         *Enclosure          `xml:"enclosure,omitempty"`
+	ItunesDuration    string              `xml:"itunes:duration,omitempty"`
+	ItunesEpisodeType string              `xml:"itunes:episodeType,omitempty"`
+	Transcripts       []PodcastTranscript `xml:"podcast:transcript"`
+	Chapters          *PodcastChapterLink `xml:"podcast:chapters,omitempty"`
+}
+
+type Enclosure struct {
+	URL    string `xml:"url,attr"`
+	Length int64  `xml:"length,attr"`
+	Type   string `xml:"type,attr"`
+}
+
+type PodcastTranscript struct {
+	URL  string `xml:"url,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type PodcastChapterLink struct {
+	URL  string `xml:"url,attr"`
+	Type string `xml:"type,attr"`
+}
+
+// category returns the text of the first category in the given domain.
+func (i Item) category(domain string) string {
+	for _, c := range i.Categories {
+		if c.Domain == domain {
+			return c.Text
+		}
+	}
+	return ""
+}
+
+// Category domains used to carry paper metadata in feed items
+const (
+	categoryArxiv    = "arxiv"
+	categoryUpvotes  = "upvotes"
+	categoryComments = "comments"
+	categoryGitHub   = "github"
+	// Summary items carry "cited/total" papers under this domain
+	categoryCitations = "citations"
+)
+
+type Category struct {
+	Domain string `xml:"domain,attr,omitempty"`
+	Text   string `xml:",chardata"`
+}
+
+type MediaThumbnail struct {
+	URL string `xml:"url,attr"`
+}
+
+// UnmarshalXML reads media:thumbnail and dc:creator by their namespaces, since the
+// prefixed tags used for marshalling never match on the way back in.
+func (i *Item) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type item Item
+	var aux struct {
+		item
+		MediaThumbnail *MediaThumbnail `xml:"http://search.yahoo.com/mrss/ thumbnail"`
+		DCCreator      string          `xml:"http://purl.org/dc/elements/1.1/ creator"`
+	}
+	if err := d.DecodeElement(&aux, &start); err != nil {
+		return err
+	}
+	*i = Item(aux.item)
+	i.Thumbnail = aux.MediaThumbnail
+	i.Creator = aux.DCCreator
+	return nil
 }
 
 type GUID struct {
@@ -94,20 +279,120 @@ type CDATA struct {
 	Text string `xml:",cdata"`
 }
 
+// Atom 1.0 structures
+type AtomFeed struct {
+	XMLName   xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID        string      `xml:"id"`
+	Title     string      `xml:"title"`
+	Subtitle  string      `xml:"subtitle,omitempty"`
+	Updated   string      `xml:"updated"`
+	Author    AtomPerson  `xml:"author"`
+	Generator string      `xml:"generator,omitempty"`
+	Links     []AtomLink  `xml:"link"`
+	Entries   []AtomEntry `xml:"entry"`
+}
+
+type AtomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Links      []AtomLink     `xml:"link"`
+	Published  string         `xml:"published,omitempty"`
+	Updated    string         `xml:"updated"`
+	Authors    []AtomPerson   `xml:"author"`
+	Categories []AtomCategory `xml:"category"`
+	Content    AtomContent    `xml:"content"`
+}
+
+type AtomPerson struct {
+	Name string `xml:"name"`
+}
+
+type AtomCategory struct {
+	Term   string `xml:"term,attr"`
+	Scheme string `xml:"scheme,attr,omitempty"`
+}
+
+type AtomContent struct {
+	Type string `xml:"type,attr"`
+	Text string `xml:",chardata"`
+}
+
+// JSON Feed 1.1 structures
+type JSONFeed struct {
+	Version     string           `json:"version"`
+	Title       string           `json:"title"`
+	HomePageURL string           `json:"home_page_url,omitempty"`
+	FeedURL     string           `json:"feed_url"`
+	Description string           `json:"description,omitempty"`
+	Authors     []JSONFeedAuthor `json:"authors"`
+	Items       []JSONFeedItem   `json:"items"`
+}
+
+type JSONFeedItem struct {
+	ID            string           `json:"id"`
+	URL           string           `json:"url,omitempty"`
+	ExternalURL   string           `json:"external_url,omitempty"`
+	Title         string           `json:"title"`
+	ContentHTML   string           `json:"content_html"`
+	Image         string           `json:"image,omitempty"`
+	DatePublished string           `json:"date_published,omitempty"`
+	Authors       []JSONFeedAuthor `json:"authors,omitempty"`
+	Tags          []string         `json:"tags,omitempty"`
+	Paper         *JSONFeedPaper   `json:"_hf_paper,omitempty"`
+}
+
+type JSONFeedAuthor struct {
+	Name string `json:"name"`
+}
+
+// JSONFeedPaper is the _hf_paper extension carrying Hugging Face paper metadata
+type JSONFeedPaper struct {
+	About     string `json:"about"`
+	ArxivID   string `json:"arxiv_id,omitempty"`
+	Upvotes   int    `json:"upvotes"`
+	Comments  int    `json:"comments"`
+	GitHubURL string `json:"github_url,omitempty"`
+}
+
+// Output formats for the paper and summary feeds
+const (
+	formatRSS  = "rss"
+	formatAtom = "atom"
+	formatJSON = "json"
+)
+
 // LLM API structures
 type LLMRequest struct {
-	Model         string    `json:"model"`
-	Messages      []Message `json:"messages"`
-	MaxTokens     int       `json:"max_tokens"`
-	Stream        bool      `json:"stream"`
-	StreamOptions struct {
-		IncludeUsage bool `json:"include_usage"`
-	} `json:"stream_options"`
-	Temperature       float64 `json:"temperature"`
-	TopP              float64 `json:"top_p"`
-	SeparateReasoning bool    `json:"separate_reasoning"`
+	Model       string    `json:"model"`
+	Messages    []Message `json:"messages"`
+	MaxTokens   int       `json:"max_tokens"`
+	Stream      bool      `json:"stream"`
+	Temperature float64   `json:"temperature"`
+	TopP        float64   `json:"top_p"`
+	// ResponseFormat switches providers that support it into JSON output mode
+	ResponseFormat *ResponseFormat `json:"response_format,omitempty"`
+}
+
+// ResponseFormat is the OpenAI-style response_format request field
+type ResponseFormat struct {
+	Type       string      `json:"type"`
+	JSONSchema *JSONSchema `json:"json_schema,omitempty"`
+}
+
+// JSONSchema names a schema for structured output
+type JSONSchema struct {
+	Name   string          `json:"name"`
+	Schema json.RawMessage `json:"schema"`
+	Strict bool            `json:"strict,omitempty"`
 }
 
+// JSON output modes, configured per task with LLM_<TASK>_JSON_MODE
+const (
+	jsonModeSchema = "json_schema" // structured output constrained to the schema
+	jsonModeObject = "json_object" // any valid JSON object
+	jsonModeOff    = "off"         // plain text, JSON is extracted from the answer
+)
+
 type Message struct {
 	Role    string `json:"role"`
 	Content string `json:"content"`
@@ -135,16 +420,80 @@ type LLMResponse struct {
 	} `json:"usage"`
 }
 
+// LLMClient sends chat completions to a language model
+type LLMClient interface {
+	Complete(ctx context.Context, messages []Message) (LLMCompletion, error)
+	// CompleteJSON asks for a JSON object matching schema, using the provider's
+	// JSON output mode when it has one.
+	CompleteJSON(ctx context.Context, messages []Message, schema JSONSchema) (LLMCompletion, error)
+}
+
+// LLMCompletion is a model answer along with the model that actually produced it
+type LLMCompletion struct {
+	Content string `json:"content"`
+	Model   string `json:"model"`
+	// Citations is set on summaries once their links are checked against the feed
+	Citations *CitationReport `json:"citations,omitempty"`
+}
+
+// LLMStatusError is returned when an LLM endpoint answers with a non-200 status
+type LLMStatusError struct {
+	URL        string
+	StatusCode int
+	Body       string
+}
+
+func (e *LLMStatusError) Error() string {
+	return fmt.Sprintf("HTTP error %d from LLM API at %s: %s", e.StatusCode, e.URL, e.Body)
+}
+
+// LLMConfig configures an OpenAI-compatible chat completions endpoint for one task
+type LLMConfig struct {
+	BaseURL     string
+	Model       string
+	APIKey      string
+	Temperature float64
+	TopP        float64
+	MaxTokens   int
+	// ContextTokens is the model's context window, used to budget long prompts
+	ContextTokens int
+	// JSONMode is the response format requested for JSON answers
+	JSONMode string
+}
+
+// llmFallback is one entry of LLM_FALLBACKS / LLM_<TASK>_FALLBACKS. Empty fields
+// inherit from the task's primary configuration.
+type llmFallback struct {
+	BaseURL   string `json:"base_url"`
+	Model     string `json:"model"`
+	APIKey    string `json:"api_key"`
+	APIKeyEnv string `json:"api_key_env"`
+}
+
+// LLM tasks, each configurable through LLM_<TASK>_* environment variables
+const (
+	llmTaskSummary      = "summary"
+	llmTaskConversation = "conversation"
+	llmTaskTLDR         = "tldr"
+)
+
+// llmTaskDefaults holds the per-task settings used when nothing is configured
+var llmTaskDefaults = map[string]LLMConfig{
+	llmTaskSummary:      {Temperature: 0.6, TopP: 0.95, MaxTokens: 4096, ContextTokens: 32768},
+	llmTaskConversation: {Temperature: 0.7, TopP: 0.95, MaxTokens: 4096, ContextTokens: 32768},
+	llmTaskTLDR:         {Temperature: 0.3, TopP: 0.95, MaxTokens: 256, ContextTokens: 32768},
+}
+
 var (
 	rdb            *redis.Client
+	llmBreakers    = newBreakerSet(llmBreakerThreshold, llmBreakerCooldown)
 	ctx            = context.Background()
 	redisConnected bool
 	initOnce       sync.Once
 	logger         = slog.New(slog.NewJSONHandler(os.Stderr, nil))
-	r2Client       *s3.Client
-	r2Bucket       string
-	r2BucketURL    string
-	r2Ready        bool
+	blobs          BlobStore
+	archive        Store
+	show           = defaultShow
 )
 
 func scrapeAbstract(ctx context.Context, url string) (string, error) {
@@ -217,38 +566,63 @@ func extractText(n *html.Node) string {
 	return text
 }
 
-func scrapePapers(ctx context.Context) ([]Paper, error) {
+// scrapePapers scrapes the listing for the given date (YYYY-MM-DD), or today's listing if date is empty.
+func scrapePapers(ctx context.Context, date string) ([]Paper, error) {
+	papers, err := scrapeListing(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+	fetchAbstracts(ctx, papers, envInt("SCRAPE_CONCURRENCY", defaultScrapeConcurrency))
+	return papers, nil
+}
+
+// scrapeListing reads the papers and their card metadata from a day's listing page,
+// without fetching abstracts.
+func scrapeListing(ctx context.Context, date string) ([]Paper, error) {
 	client := &http.Client{
 		Timeout: scrapeTimeout,
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", baseURL, nil)
+	listingURL := baseURL
+	if date != "" {
+		listingURL = baseURL + "?date=" + date
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", listingURL, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request for %s: %w", baseURL, err)
+		return nil, fmt.Errorf("failed to create request for %s: %w", listingURL, err)
 	}
 
 	resp, err := client.Do(req)
 	if err != nil {
 		if errors.Is(err, context.DeadlineExceeded) {
-			return nil, fmt.Errorf("timeout fetching papers from %s: %w", baseURL, err)
+			return nil, fmt.Errorf("timeout fetching papers from %s: %w", listingURL, err)
 		}
-		return nil, fmt.Errorf("failed to fetch papers from %s: %w", baseURL, err)
+		return nil, fmt.Errorf("failed to fetch papers from %s: %w", listingURL, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to fetch papers from %s: status code %d", baseURL, resp.StatusCode)
+		return nil, fmt.Errorf("failed to fetch papers from %s: status code %d", listingURL, resp.StatusCode)
 	}
 
 	doc, err := html.Parse(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse HTML from %s: %w", baseURL, err)
+		return nil, fmt.Errorf("failed to parse HTML from %s: %w", listingURL, err)
 	}
 
 	var papers []Paper
 
 	var crawler func(*html.Node)
 	crawler = func(node *html.Node) {
+		// Each paper on the listing is rendered as an <article> card holding the metadata
+		if node.Type == html.ElementNode && node.Data == "article" {
+			if paper, ok := parsePaperCard(node); ok {
+				papers = append(papers, paper)
+				return
+			}
+		}
+		// Fall back to bare headings in case the card markup changes
 		if node.Type == html.ElementNode && node.Data == "h3" {
 			var title, href string
 			for c := node.FirstChild; c != nil; c = c.NextSibling {
@@ -264,18 +638,10 @@ func scrapePapers(ctx context.Context) ([]Paper, error) {
 			}
 
 			if href != "" {
-				url := fmt.Sprintf("https://huggingface.co%s", href)
-				abstract, err := scrapeAbstract(ctx, url)
-				if err != nil {
-					logger.Error("Failed to extract abstract", "url", url, "error", err)
-					abstract = "[Abstract not available]" // Placeholder
-				}
-
 				papers = append(papers, Paper{
-					Title:    strings.TrimSpace(title),
-					URL:      url,
-					Abstract: abstract,
-					PubDate:  time.Now().UTC(),
+					Title:   strings.TrimSpace(title),
+					URL:     fmt.Sprintf("https://huggingface.co%s", href),
+					ArxivID: arxivIDFromPath(href),
 				})
 			}
 		}
@@ -285,607 +651,4182 @@ func scrapePapers(ctx context.Context) ([]Paper, error) {
 	}
 	crawler(doc)
 
-	// Limit number of papers
+	// Limit number of papers before fetching abstracts so we never fetch more than we publish
 	if len(papers) > maxPapers {
 		papers = papers[:maxPapers]
 	}
 
+	pubDate := listingTime(date)
+	for i := range papers {
+		papers[i].PubDate = pubDate
+	}
+
 	return papers, nil
 }
 
-func generateRSS(papers []Paper, requestURL string) ([]byte, error) {
-	items := make([]Item, len(papers))
-	for i, paper := range papers {
-		items[i] = Item{
-			Title:       paper.Title,
-			Link:        paper.URL,
-			Description: CDATA{Text: paper.Abstract},
-			PubDate:     paper.PubDate.Format(time.RFC1123Z),
-			GUID: GUID{
-				IsPermaLink: true,
-				Text:        paper.URL,
-			},
+var arxivIDPattern = regexp.MustCompile(`^\d{4}\.\d{4,5}$`)
+
+// parsePaperCard extracts a paper and its listing metadata from an <article> card.
+func parsePaperCard(card *html.Node) (Paper, bool) {
+	var paper Paper
+	var href string
+
+	var walk func(*html.Node)
+	walk = func(node *html.Node) {
+		if node.Type == html.ElementNode {
+			switch node.Data {
+			case "h3":
+				if href == "" {
+					for c := node.FirstChild; c != nil; c = c.NextSibling {
+						if c.Type == html.ElementNode && c.Data == "a" {
+							href = getAttr(c, "href")
+							paper.Title = strings.TrimSpace(extractText(c))
+							break
+						}
+					}
+				}
+				return
+			case "li":
+				// Author avatars carry the author name in the title attribute
+				if name := strings.TrimSpace(getAttr(node, "title")); name != "" {
+					paper.Authors = append(paper.Authors, name)
+					return
+				}
+			case "img":
+				if paper.Thumbnail == "" {
+					paper.Thumbnail = getAttr(node, "src")
+				}
+			case "div":
+				if paper.Upvotes == 0 && hasClass(node, "leading-none") {
+					if n, err := strconv.Atoi(strings.TrimSpace(extractText(node))); err == nil {
+						paper.Upvotes = n
+					}
+				}
+			case "a":
+				link := getAttr(node, "href")
+				if paper.GitHubURL == "" && strings.HasPrefix(link, "https://github.com/") {
+					paper.GitHubURL = link
+				}
+				if strings.HasSuffix(link, "#community") {
+					if n, err := strconv.Atoi(strings.TrimSpace(extractText(node))); err == nil {
+						paper.Comments = n
+					}
+				}
+			}
+		}
+		for c := node.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
 		}
 	}
+	walk(card)
 
-	rss := RSS{
-		Version: "2.0",
-		XMLNS:   "http://www.w3.org/2005/Atom",
-		Channel: Channel{
-			Title:         "宝の知識: Hugging Face 論文フィード",
-			Link:          baseURL,
-			Description:   "最先端のAI論文をお届けする、Takara.aiの厳選フィード",
-			LastBuildDate: time.Now().UTC().Format(time.RFC1123Z),
-			AtomLink: AtomLink{
-				Href: requestURL,
-				Rel:  "self",
-				Type: "application/rss+xml",
-			},
-			Items: items,
-		},
-	}
-
-	// Add XML header and proper encoding
-	output, err := xml.MarshalIndent(rss, "", "  ")
-	if err != nil {
-		return nil, err
+	if href == "" {
+		return Paper{}, false
 	}
 
-	// Prepend the XML header
-	return append([]byte(xml.Header), output...), nil
+	paper.URL = fmt.Sprintf("https://huggingface.co%s", href)
+	paper.ArxivID = arxivIDFromPath(href)
+	return paper, true
 }
 
-// Simple CORS middleware
-func corsMiddleware(next http.HandlerFunc) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+// arxivIDFromPath returns the arXiv identifier from a /papers/<id> link, if it is one.
+func arxivIDFromPath(href string) string {
+	id := strings.TrimPrefix(href, "/papers/")
+	if arxivIDPattern.MatchString(id) {
+		return id
+	}
+	return ""
+}
 
-		if r.Method == "OPTIONS" {
-			w.WriteHeader(http.StatusOK)
-			return
+func getAttr(n *html.Node, key string) string {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return attr.Val
 		}
-		next(w, r)
 	}
+	return ""
 }
 
-func initRedis() {
-	redisURL := os.Getenv("KV_URL")
-	if redisURL == "" {
-		return
+func hasClass(n *html.Node, class string) bool {
+	for _, c := range strings.Fields(getAttr(n, "class")) {
+		if c == class {
+			return true
+		}
 	}
+	return false
+}
 
-	opt, err := redis.ParseURL(redisURL)
-	if err != nil {
-		logger.Error("Error parsing Redis URL", "error", err)
-		return
+// fetchAbstracts fills in the missing Abstract of each paper using at most workers
+// concurrent requests. Papers are updated in place, so listing order is kept.
+func fetchAbstracts(ctx context.Context, papers []Paper, workers int) {
+	if workers < 1 {
+		workers = 1
 	}
 
-	rdb = redis.NewClient(opt)
-	pingErr := rdb.Ping(ctx).Err()
-	if pingErr != nil {
-		logger.Error("Error connecting to Redis", "error", pingErr)
-		return
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers && w < len(papers); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				// Each paper gets its own deadline so a slow page can't starve the others
+				paperCtx, cancel := context.WithTimeout(ctx, scrapeTimeout)
+				abstract, err := scrapeAbstract(paperCtx, papers[i].URL)
+				cancel()
+				if err != nil {
+					logger.Error("Failed to extract abstract", "url", papers[i].URL, "error", err)
+					abstract = "[Abstract not available]" // Placeholder
+				}
+				papers[i].Abstract = abstract
+			}
+		}()
 	}
 
-	redisConnected = true
-	logger.Info("Successfully connected to Redis")
+	for i := range papers {
+		if papers[i].Abstract == "" {
+			jobs <- i
+		}
+	}
+	close(jobs)
+	wg.Wait()
 }
 
-func initR2() {
-	endpoint := os.Getenv("R2_ENDPOINT")
-	accessKey := os.Getenv("R2_ACCESS_KEY_ID")
-	secretKey := os.Getenv("R2_SECRET_ACCESS_KEY")
-	bucket := os.Getenv("R2_BUCKET_NAME")
-	if endpoint == "" || accessKey == "" || secretKey == "" || bucket == "" {
-		logger.Warn("Cloudflare R2 env vars missing, audio podcast will not be stored in R2")
-		return
+// listingTime returns the publication time for a listing date, or now for today's listing.
+func listingTime(date string) time.Time {
+	if date == "" {
+		return time.Now().UTC()
 	}
-	cfg, err := config.LoadDefaultConfig(context.TODO(),
-		config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(accessKey, secretKey, "")),
-		config.WithRegion("auto"),
-		config.WithEndpointResolverWithOptions(aws.EndpointResolverWithOptionsFunc(
-			func(service, region string, options ...interface{}) (aws.Endpoint, error) {
-				return aws.Endpoint{URL: endpoint, SigningRegion: "auto"}, nil
-			},
-		)),
-	)
+	t, err := time.Parse(dateLayout, date)
 	if err != nil {
-		logger.Error("Failed to init R2 S3 client", "error", err)
-		return
+		return time.Now().UTC()
 	}
-	r2Client = s3.NewFromConfig(cfg)
-	r2Bucket = bucket
-	r2BucketURL = endpoint
-	r2Ready = true
-	logger.Info("Cloudflare R2 S3 client initialized")
+	return t
 }
 
-func r2PutPodcast(ctx context.Context, key string, data []byte) error {
-	if !r2Ready {
-		return fmt.Errorf("R2 not initialized")
+// parseDateParam validates the optional ?date=YYYY-MM-DD query parameter.
+// It returns an empty string when no date or today's date was requested.
+func parseDateParam(r *http.Request) (string, error) {
+	raw := r.URL.Query().Get("date")
+	if raw == "" {
+		return "", nil
 	}
-	logger.Info("Uploading podcast to R2", "key", key, "size", len(data))
-	_, err := r2Client.PutObject(ctx, &s3.PutObjectInput{
-		Bucket:      &r2Bucket,
-		Key:         &key,
-		Body:        bytes.NewReader(data),
-		ContentType: aws.String("audio/mpeg"),
-		ACL:         types.ObjectCannedACLPublicRead,
-	})
+	t, err := time.Parse(dateLayout, raw)
 	if err != nil {
-		logger.Error("Failed to upload podcast to R2", "key", key, "error", err)
-	} else {
-		logger.Info("Successfully uploaded podcast to R2", "key", key, "size", len(data))
+		return "", fmt.Errorf("invalid date %q, expected YYYY-MM-DD", raw)
 	}
-	return err
-}
-
-func r2GetPodcast(ctx context.Context, key string) ([]byte, error) {
-	if !r2Ready {
-		return nil, fmt.Errorf("R2 not initialized")
+	if t.After(time.Now().UTC()) {
+		return "", fmt.Errorf("date %s is in the future", raw)
 	}
-	resp, err := r2Client.GetObject(ctx, &s3.GetObjectInput{
-		Bucket: &r2Bucket,
-		Key:    &key,
-	})
-	if err != nil {
-		return nil, err
+	// Today's listing is still changing, so it shares the undated keys
+	date := t.Format(dateLayout)
+	if isToday(date) {
+		return "", nil
 	}
-	defer resp.Body.Close()
-	return io.ReadAll(resp.Body)
+	return date, nil
 }
 
-func getCachedFeed(ctx context.Context, requestURL string) ([]byte, error) {
-	if !redisConnected {
-		return generateFeedDirect(ctx, requestURL)
-	}
+// isToday reports whether date is today's listing date in UTC.
+func isToday(date string) bool {
+	return date == time.Now().UTC().Format(dateLayout)
+}
 
-	// Try to get from cache first
-	cachedData, err := rdb.Get(ctx, cacheKey).Bytes()
-	if err == nil {
-		return cachedData, nil
-	} else if !errors.Is(err, redis.Nil) {
-		logger.Warn("Redis Get failed, generating feed directly", "key", cacheKey, "error", err)
+// dateCacheKey scopes a cache key to a listing date; the empty date is today's listing.
+func dateCacheKey(key, date string) string {
+	if date == "" {
+		return key
 	}
+	return key + ":" + date
+}
 
-	// Cache miss or Redis error, generate new feed
-	feed, err := generateFeedDirect(ctx, requestURL)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate direct feed: %w", err)
+// dateCacheDuration keeps past listings longer since they no longer change.
+func dateCacheDuration(date string) time.Duration {
+	if date == "" || isToday(date) {
+		return cacheDuration
 	}
+	return historyCacheDuration
+}
 
-	// Cache the new feed if Redis is connected
-	if redisConnected {
-		err = rdb.Set(ctx, cacheKey, feed, cacheDuration).Err()
-		if err != nil {
-			logger.Warn("Failed to cache feed", "key", cacheKey, "error", err)
-		}
-	}
+// cacheStatus reports in the X-Cache header how a response was served.
+type cacheStatus string
 
-	return feed, nil
+const (
+	cacheHit   cacheStatus = "HIT"
+	cacheStale cacheStatus = "STALE"
+	cacheMiss  cacheStatus = "MISS"
+)
+
+// cacheResult describes how a response was served, for the X-Cache and Age headers.
+type cacheResult struct {
+	Status cacheStatus
+	Age    time.Duration
 }
 
-func generateFeedDirect(ctx context.Context, requestURL string) ([]byte, error) {
-	// Pass context to scrapePapers
-	papers, err := scrapePapers(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed scraping papers: %w", err)
-	}
-	return generateRSS(papers, requestURL)
+// cacheEntry is a cached artifact with the time it was generated. Past SoftTTL it
+// is still served, but refreshed in the background; Redis drops it at HardTTL.
+type cacheEntry struct {
+	Data        []byte
+	GeneratedAt time.Time
+	SoftTTL     time.Duration
+	HardTTL     time.Duration
 }
 
-// updateAllCaches generates fresh feed and summary data and updates both caches.
-func updateAllCaches(ctx context.Context) error {
-	if !redisConnected {
-		return fmt.Errorf("redis not connected, cannot update caches")
-	}
+func (e *cacheEntry) Age() time.Duration {
+	return time.Since(e.GeneratedAt)
+}
 
-	logger.Info("Starting cache update for feed and summary")
+func (e *cacheEntry) Stale() bool {
+	return e.Age() >= e.SoftTTL
+}
 
-	// 1. Generate fresh feed data
-	// Use baseURL for the canonical cache content's requestURL in generateRSS
-	freshFeedBytes, err := generateFeedDirect(ctx, baseURL)
+// cacheGet reads a cache entry, returning redis.Nil if there is none.
+func cacheGet(ctx context.Context, key string) (*cacheEntry, error) {
+	fields, err := rdb.HGetAll(ctx, key).Result()
 	if err != nil {
-		return fmt.Errorf("failed to generate direct feed for cache update: %w", err)
+		return nil, err
 	}
-
-	// 2. Update feed cache
-	// Use a separate context for Redis operations if needed, but reqCtx is usually fine
-	// Adding a small timeout specifically for Redis Set might be wise.
-	err = rdb.Set(ctx, cacheKey, freshFeedBytes, cacheDuration).Err()
+	data, ok := fields["data"]
+	if !ok {
+		return nil, redis.Nil
+	}
+	generatedAt, err := strconv.ParseInt(fields["generated_at"], 10, 64)
 	if err != nil {
-		// Log the error but continue to attempt summary update if possible
-		logger.Error("Failed to update feed cache", "key", cacheKey, "error", err)
-		// Decide if this error should prevent summary update (e.g., return err here)
-		// For now, we log and continue.
-	} else {
-		logger.Info("Successfully updated feed cache", "key", cacheKey)
+		return nil, fmt.Errorf("malformed cache entry %s: %w", key, err)
 	}
-
-	// --- Summary Update ---
-
-	// 3. Parse the *fresh* feed bytes to markdown
-	markdown, err := parseRSSToMarkdown(string(freshFeedBytes))
+	softTTL, err := strconv.ParseInt(fields["soft_ttl"], 10, 64)
 	if err != nil {
-		// If parsing fails, we cannot generate a summary. Log and return error.
-		logger.Error("Failed to parse fresh feed to markdown for summary update", "error", err)
-		return fmt.Errorf("failed to parse fresh feed to markdown: %w", err)
+		return nil, fmt.Errorf("malformed cache entry %s: %w", key, err)
 	}
-
-	// 4. Summarize markdown with LLM
-	// Use a context with appropriate timeout for the LLM call
-	summaryCtx, cancel := context.WithTimeout(ctx, llmTimeout)
-	defer cancel()
-	summaryContent, err := summarizeWithLLM(summaryCtx, markdown)
+	hardTTL, err := strconv.ParseInt(fields["hard_ttl"], 10, 64)
 	if err != nil {
-		// If LLM fails, log and return error.
-		logger.Error("Failed to summarize markdown with LLM for cache update", "error", err)
-		return fmt.Errorf("failed to summarize markdown with LLM: %w", err)
+		return nil, fmt.Errorf("malformed cache entry %s: %w", key, err)
 	}
+	return &cacheEntry{
+		Data:        []byte(data),
+		GeneratedAt: time.Unix(generatedAt, 0),
+		SoftTTL:     time.Duration(softTTL) * time.Second,
+		HardTTL:     time.Duration(hardTTL) * time.Second,
+	}, nil
+}
 
-	// 5. Generate summary RSS
-	// Use baseURL for the canonical requestURL
-	summaryRSSBytes, err := generateSummaryRSS(summaryContent, baseURL)
-	if err != nil {
-		// If summary RSS generation fails, log and return error.
-		logger.Error("Failed to generate summary RSS for cache update", "error", err)
-		return fmt.Errorf("failed to generate summary RSS: %w", err)
-	}
+// cacheSet stores a freshly generated artifact. It turns stale after softTTL and
+// expires after cacheHardTTLFactor times that.
+func cacheSet(ctx context.Context, key string, data []byte, softTTL time.Duration) error {
+	hardTTL := softTTL * cacheHardTTLFactor
+	_, err := rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		// Replace the whole entry, including plain string values cached before entries had metadata
+		pipe.Del(ctx, key)
+		pipe.HSet(ctx, key,
+			"data", data,
+			"generated_at", time.Now().Unix(),
+			"soft_ttl", int64(softTTL/time.Second),
+			"hard_ttl", int64(hardTTL/time.Second))
+		pipe.Expire(ctx, key, hardTTL)
+		return nil
+	})
+	return err
+}
 
-	logger.Info("Successfully updated both feed and summary caches")
-	// 6. Update summary cache
-	err = rdb.Set(ctx, summaryCacheKey, summaryRSSBytes, cacheDuration).Err()
-	if err != nil {
-		// Log the error, but the feed cache might have updated successfully.
-		logger.Error("Failed to update summary cache", "key", summaryCacheKey, "error", err)
-		// Decide if this should return an overall error.
-		// Returning error indicates the full update wasn't successful.
-		return fmt.Errorf("failed to update summary cache: %w", err)
-	} else {
-		logger.Info("Successfully updated summary cache", "key", summaryCacheKey)
+// cachedArtifact serves key from the cache, generating and caching it on a miss.
+// Entries past their soft TTL are regenerated by a single reader while the others are
+// served them as they are. Without Redis every call generates.
+func cachedArtifact(ctx context.Context, key string, softTTL time.Duration, generate func(context.Context) ([]byte, error)) ([]byte, cacheResult, error) {
+	return serveCached(ctx, key, softTTL, true, generate)
+}
+
+// pinnedArtifact is cachedArtifact without the stale refresh, for artifacts that
+// others were built from. Stale entries are served until they expire, so a refresh
+// never replaces them behind the back of what was made from them.
+func pinnedArtifact(ctx context.Context, key string, softTTL time.Duration, generate func(context.Context) ([]byte, error)) ([]byte, cacheResult, error) {
+	return serveCached(ctx, key, softTTL, false, generate)
+}
+
+func serveCached(ctx context.Context, key string, softTTL time.Duration, refresh bool, generate func(context.Context) ([]byte, error)) ([]byte, cacheResult, error) {
+	if redisConnected {
+		entry, err := cacheGet(ctx, key)
+		if err == nil {
+			if !entry.Stale() {
+				logger.Info("Cache hit", "key", key)
+				return entry.Data, cacheResult{Status: cacheHit, Age: entry.Age()}, nil
+			}
+			if refresh {
+				if data := refreshStale(ctx, key, softTTL, generate); data != nil {
+					return data, cacheResult{Status: cacheMiss}, nil
+				}
+			}
+			logger.Info("Serving stale cache entry", "key", key, "age", entry.Age().Round(time.Second).String())
+			return entry.Data, cacheResult{Status: cacheStale, Age: entry.Age()}, nil
+		} else if !errors.Is(err, redis.Nil) {
+			logger.Warn("Redis Get failed, generating directly", "key", key, "error", err)
+		}
 	}
 
-	// Generate podcast conversation
-	conversation, err := generatePodcastConversation(ctx, string(summaryRSSBytes))
+	data, err := generate(ctx)
 	if err != nil {
-		logger.Error("Failed to generate podcast conversation", "error", err)
-		return fmt.Errorf("failed to generate podcast conversation: %w", err)
+		return nil, cacheResult{}, err
+	}
+	if redisConnected {
+		if err := cacheSet(ctx, key, data, softTTL); err != nil {
+			logger.Warn("Failed to cache artifact", "key", key, "error", err)
+		}
 	}
+	return data, cacheResult{Status: cacheMiss}, nil
+}
 
-	// 7. Update conversation cache
-	err = rdb.Set(ctx, conversationCacheKey, []byte(conversation), cacheDuration).Err()
+// refreshStale regenerates a stale entry in the request path, since serverless
+// instances are frozen once the response is written and a refresh left running after
+// it would never finish. A Redis lock picks one reader on any instance to refresh the
+// key; the others are served the stale copy right away. The refresh is bounded by
+// cacheRefreshTimeout, under the function's maxDuration, and the lock expires with it
+// in case the instance dies before releasing it. It returns nil if this reader didn't
+// refresh the entry or the refresh failed, so the stale copy is served instead.
+func refreshStale(ctx context.Context, key string, softTTL time.Duration, generate func(context.Context) ([]byte, error)) []byte {
+	lock := key + ":refresh"
+	acquired, err := rdb.SetNX(ctx, lock, 1, cacheRefreshTimeout).Result()
 	if err != nil {
-		logger.Error("Failed to update conversation cache", "key", conversationCacheKey, "error", err)
-		return fmt.Errorf("failed to update conversation cache: %w", err)
-	} else {
-		logger.Info("Successfully updated conversation cache", "key", conversationCacheKey)
+		logger.Warn("Failed to take cache refresh lock", "key", key, "error", err)
+		return nil
+	}
+	if !acquired {
+		return nil
 	}
 
-	// In updateAllCaches function
-	// After generating summary RSS
-
-	// Generate and update conversation with proper error handling
-	logger.Info("Starting conversation cache update")
+	// Finish the refresh and release the lock even if the reader goes away
+	detached := context.WithoutCancel(ctx)
+	defer rdb.Del(detached, lock)
+	refreshCtx, cancel := context.WithTimeout(detached, cacheRefreshTimeout)
+	defer cancel()
 
-	// Parse RSS bytes to get text content for conversation generation
-	conversation, err = generatePodcastConversation(ctx, string(summaryRSSBytes))
+	data, err := generate(refreshCtx)
 	if err != nil {
-		logger.Error("Failed to generate podcast conversation", "error", err)
-		return fmt.Errorf("failed to generate podcast conversation: %w", err)
+		logger.Error("Cache refresh failed, serving stale entry", "key", key, "error", err)
+		return nil
+	}
+	if err := cacheSet(detached, key, data, softTTL); err != nil {
+		logger.Warn("Failed to cache refreshed artifact", "key", key, "error", err)
 	}
+	logger.Info("Refreshed stale cache entry", "key", key)
+	return data
+}
 
-	// Add logging before cache update
-	logger.Info("Attempting to update conversation cache",
-		"key", conversationCacheKey,
-		"contentLength", len(conversation))
+// setCacheHeaders reports how a response was served: X-Cache is HIT, STALE or MISS
+// and Age is the number of seconds since it was generated.
+func setCacheHeaders(w http.ResponseWriter, result cacheResult) {
+	if result.Status == "" {
+		return
+	}
+	w.Header().Set("X-Cache", string(result.Status))
+	w.Header().Set("Age", strconv.FormatInt(int64(result.Age/time.Second), 10))
+}
 
-	// Update conversation cache with proper error handling
-	err = rdb.Set(ctx, conversationCacheKey, []byte(conversation), cacheDuration).Err()
-	if err != nil {
-		logger.Error("Failed to update conversation cache",
-			"key", conversationCacheKey,
-			"error", err)
-		return fmt.Errorf("failed to update conversation cache: %w", err)
+// envInt reads a positive integer from the environment, falling back to def.
+func envInt(name string, def int) int {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return def
 	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 1 {
+		logger.Warn("Invalid integer env var, using default", "name", name, "value", raw, "default", def)
+		return def
+	}
+	return n
+}
 
-	logger.Info("Successfully updated conversation cache",
-		"key", conversationCacheKey)
+// Languages for channel metadata and summaries. Paper feeds keep their original
+// Japanese branding by default, summaries are written in English unless asked otherwise.
+const (
+	defaultFeedLang    = "ja"
+	defaultSummaryLang = "en"
+)
 
-	// After conversation cache update
-	logger.Info("Starting podcast cache update")
+// translations holds the localized channel metadata and summary strings per language.
+// Keys missing from a language fall back to English.
+var translations = map[string]map[string]string{
+	"en": {
+		"language":            "English",
+		"date":                "January 2, 2006",
+		"feed.title":          "Takara Knowledge: Hugging Face Papers Feed",
+		"feed.description":    "Takara.ai's curated feed of cutting-edge AI papers",
+		"tldr.title":          "Takara Knowledge: Hugging Face Papers Feed (TL;DR)",
+		"tldr.description":    "Cutting-edge AI papers in a sentence, curated by Takara.ai",
+		"weekly.title":        "Takara Knowledge: Top Papers This Week",
+		"weekly.description":  "Takara.ai's weekly digest of the most upvoted AI papers from the past 7 days",
+		"monthly.title":       "Takara Knowledge: Top Papers This Month",
+		"monthly.description": "Takara.ai's monthly digest of the most upvoted AI papers from the past 30 days",
+		"summary.title":       "Takara TLDR",
+		"summary.description": "Daily summaries of AI research papers from takara.ai",
+		"summary.item":        "AI Research Papers Summary for %s",
+		"weekly.summary":      "Weekly AI Research Digest for %s",
+		"monthly.summary":     "Monthly AI Research Digest for %s",
+		"summary.headline":    "Morning Headline",
+		"summary.whatsnew":    "What's New",
+	},
+	"ja": {
+		"language":            "Japanese",
+		"date":                "2006年1月2日",
+		"feed.title":          "宝の知識: Hugging Face 論文フィード",
+		"feed.description":    "最先端のAI論文をお届けする、Takara.aiの厳選フィード",
+		"tldr.title":          "宝の知識: Hugging Face 論文フィード (TL;DR)",
+		"tldr.description":    "最先端のAI論文を一言で。Takara.aiの厳選フィード",
+		"weekly.title":        "宝の知識: 今週のトップ論文",
+		"weekly.description":  "過去7日間で最も支持されたAI論文をお届けする、Takara.aiの週間ダイジェスト",
+		"monthly.title":       "宝の知識: 今月のトップ論文",
+		"monthly.description": "過去30日間で最も支持されたAI論文をお届けする、Takara.aiの月間ダイジェスト",
+		"summary.title":       "Takara TLDR",
+		"summary.description": "takara.aiによるAI研究論文の毎日のまとめ",
+		"summary.item":        "%s のAI研究論文まとめ",
+		"weekly.summary":      "%s までの週間AI研究ダイジェスト",
+		"monthly.summary":     "%s までの月間AI研究ダイジェスト",
+		"summary.headline":    "今朝のヘッドライン",
+		"summary.whatsnew":    "新着情報",
+	},
+	"de": {
+		"language":            "German",
+		"date":                "02.01.2006",
+		"feed.title":          "Takara-Wissen: Hugging Face Paper-Feed",
+		"feed.description":    "Der kuratierte Feed von Takara.ai mit den neuesten KI-Papern",
+		"tldr.title":          "Takara-Wissen: Hugging Face Paper-Feed (TL;DR)",
+		"tldr.description":    "Die neuesten KI-Paper in einem Satz, kuratiert von Takara.ai",
+		"weekly.title":        "Takara-Wissen: Top-Paper der Woche",
+		"weekly.description":  "Der Wochenrückblick von Takara.ai mit den meistgevoteten KI-Papern der letzten 7 Tage",
+		"monthly.title":       "Takara-Wissen: Top-Paper des Monats",
+		"monthly.description": "Der Monatsrückblick von Takara.ai mit den meistgevoteten KI-Papern der letzten 30 Tage",
+		"summary.title":       "Takara TLDR",
+		"summary.description": "Tägliche Zusammenfassungen von KI-Forschungspapern von takara.ai",
+		"summary.item":        "Zusammenfassung der KI-Forschungspaper vom %s",
+		"weekly.summary":      "Wöchentlicher KI-Forschungsüberblick bis %s",
+		"monthly.summary":     "Monatlicher KI-Forschungsüberblick bis %s",
+		"summary.headline":    "Schlagzeile des Morgens",
+		"summary.whatsnew":    "Was gibt es Neues",
+	},
+	"fr": {
+		"language":            "French",
+		"date":                "02/01/2006",
+		"feed.title":          "Savoir Takara : flux des articles Hugging Face",
+		"feed.description":    "La sélection Takara.ai des articles d'IA les plus récents",
+		"tldr.title":          "Savoir Takara : flux des articles Hugging Face (TL;DR)",
+		"tldr.description":    "Les derniers articles d'IA en une phrase, sélectionnés par Takara.ai",
+		"weekly.title":        "Savoir Takara : les meilleurs articles de la semaine",
+		"weekly.description":  "Le condensé hebdomadaire Takara.ai des articles d'IA les plus votés des 7 derniers jours",
+		"monthly.title":       "Savoir Takara : les meilleurs articles du mois",
+		"monthly.description": "Le condensé mensuel Takara.ai des articles d'IA les plus votés des 30 derniers jours",
+		"summary.title":       "Takara TLDR",
+		"summary.description": "Résumés quotidiens d'articles de recherche en IA par takara.ai",
+		"summary.item":        "Résumé des articles de recherche en IA du %s",
+		"weekly.summary":      "Condensé hebdomadaire de la recherche en IA au %s",
+		"monthly.summary":     "Condensé mensuel de la recherche en IA au %s",
+		"summary.headline":    "À la une ce matin",
+		"summary.whatsnew":    "Quoi de neuf",
+	},
+	"es": {
+		"language":            "Spanish",
+		"date":                "02/01/2006",
+		"feed.title":          "Saber Takara: feed de artículos de Hugging Face",
+		"feed.description":    "La selección de Takara.ai de los artículos de IA más recientes",
+		"tldr.title":          "Saber Takara: feed de artículos de Hugging Face (TL;DR)",
+		"tldr.description":    "Los artículos de IA más recientes en una frase, seleccionados por Takara.ai",
+		"weekly.title":        "Saber Takara: los mejores artículos de la semana",
+		"weekly.description":  "El resumen semanal de Takara.ai con los artículos de IA más votados de los últimos 7 días",
+		"monthly.title":       "Saber Takara: los mejores artículos del mes",
+		"monthly.description": "El resumen mensual de Takara.ai con los artículos de IA más votados de los últimos 30 días",
+		"summary.title":       "Takara TLDR",
+		"summary.description": "Resúmenes diarios de artículos de investigación en IA de takara.ai",
+		"summary.item":        "Resumen de artículos de investigación en IA del %s",
+		"weekly.summary":      "Resumen semanal de investigación en IA al %s",
+		"monthly.summary":     "Resumen mensual de investigación en IA al %s",
+		"summary.headline":    "Titular de la mañana",
+		"summary.whatsnew":    "Novedades",
+	},
+	"zh": {
+		"language":            "Simplified Chinese",
+		"date":                "2006年1月2日",
+		"feed.title":          "宝的知识：Hugging Face 论文订阅",
+		"feed.description":    "Takara.ai 精选的前沿 AI 论文订阅",
+		"tldr.title":          "宝的知识：Hugging Face 论文订阅 (TL;DR)",
+		"tldr.description":    "一句话读懂前沿 AI 论文，由 Takara.ai 精选",
+		"weekly.title":        "宝的知识：本周热门论文",
+		"weekly.description":  "Takara.ai 每周精选过去 7 天最受欢迎的 AI 论文",
+		"monthly.title":       "宝的知识：本月热门论文",
+		"monthly.description": "Takara.ai 每月精选过去 30 天最受欢迎的 AI 论文",
+		"summary.title":       "Takara TLDR",
+		"summary.description": "takara.ai 每日 AI 研究论文摘要",
+		"summary.item":        "%s AI 研究论文摘要",
+		"weekly.summary":      "截至 %s 的每周 AI 研究摘要",
+		"monthly.summary":     "截至 %s 的每月 AI 研究摘要",
+		"summary.headline":    "今日头条",
+		"summary.whatsnew":    "最新进展",
+	},
+}
 
-	// Generate audio podcast from conversation
-	audioData, err := generateaudiopodcast(ctx, conversation)
-	if err != nil {
-		logger.Error("Failed to generate podcast audio", "error", err)
-		return fmt.Errorf("failed to generate podcast audio: %w", err)
+// translate returns the localized string for key, falling back to English.
+func translate(lang, key string) string {
+	if s, ok := translations[lang][key]; ok {
+		return s
 	}
+	return translations["en"][key]
+}
 
-	// update cache in R2
-	if r2Ready {
-		err = r2PutPodcast(ctx, "podcast-latest.mp3", audioData)
-		if err != nil {
-			logger.Error("Failed to upload podcast to R2", "key", "podcast-latest.mp3", "error", err)
-			return fmt.Errorf("failed to upload podcast to R2: %w", err)
+// localizedDate formats t the way readers of lang expect.
+func localizedDate(lang string, t time.Time) string {
+	return t.Format(translate(lang, "date"))
+}
+
+// parseLangParam validates the optional ?lang= query parameter against the
+// translation tables. It returns def when no language was requested.
+func parseLangParam(r *http.Request, def string) (string, error) {
+	raw := strings.ToLower(r.URL.Query().Get("lang"))
+	if raw == "" {
+		return def, nil
+	}
+	if _, ok := translations[raw]; !ok {
+		langs := make([]string, 0, len(translations))
+		for lang := range translations {
+			langs = append(langs, lang)
 		}
-	} else {
-		logger.Warn("R2 not ready, podcast will not be stored in R2")
+		sort.Strings(langs)
+		return "", fmt.Errorf("unsupported lang %q, expected one of %s", raw, strings.Join(langs, ", "))
 	}
+	return raw, nil
+}
 
-	logger.Info("Successfully updated podcast cache",
-		"key", "podcast-latest.mp3",
-		"size", len(audioData))
+// langCacheKey scopes a cache key or archive kind to a language; the default
+// language keeps the unscoped key so existing entries stay valid.
+func langCacheKey(key, lang, def string) string {
+	if lang == def {
+		return key
+	}
+	return key + ":" + lang
+}
 
-	logger.Info("Successfully updated all caches (feed, summary, conversation, and podcast)")
-	return nil
+func generateRSS(papers []Paper, requestURL string) ([]byte, error) {
+	return marshalPaperRSS(papers, requestURL, defaultFeedLang, "feed")
 }
 
-func parseRSSToMarkdown(xmlContent string) (string, error) {
-	var rss RSS
-	err := xml.Unmarshal([]byte(xmlContent), &rss)
+// marshalPaperRSS renders papers as an RSS channel whose title and description are
+// the lang translations of <name>.title and <name>.description.
+func marshalPaperRSS(papers []Paper, requestURL, lang, name string) ([]byte, error) {
+	items := make([]Item, len(papers))
+	for i, paper := range papers {
+		items[i] = paperItem(paper)
+	}
+
+	rss := RSS{
+		Version:    "2.0",
+		XMLNS:      "http://www.w3.org/2005/Atom",
+		XMLNSMedia: "http://search.yahoo.com/mrss/",
+		XMLNSDC:    "http://purl.org/dc/elements/1.1/",
+		Channel: Channel{
+			Title:         translate(lang, name+".title"),
+			Link:          baseURL,
+			Description:   translate(lang, name+".description"),
+			LastBuildDate: time.Now().UTC().Format(time.RFC1123Z),
+			Language:      lang,
+			AtomLink: AtomLink{
+				Href: requestURL,
+				Rel:  "self",
+				Type: "application/rss+xml",
+			},
+			Items: items,
+		},
+	}
+
+	// Add XML header and proper encoding
+	output, err := xml.MarshalIndent(rss, "", "  ")
 	if err != nil {
-		return "", fmt.Errorf("failed to unmarshal RSS XML: %w", err)
+		return nil, err
 	}
 
-	// Format date
-	var formattedDate string
-	parsedDate, err := time.Parse(time.RFC1123Z, rss.Channel.LastBuildDate)
+	// Prepend the XML header
+	return append([]byte(xml.Header), output...), nil
+}
+
+// paperItem converts a paper and its listing metadata into a feed item.
+func paperItem(paper Paper) Item {
+	item := Item{
+		Title:       paper.Title,
+		Link:        paper.URL,
+		Description: CDATA{Text: paperDescription(paper)},
+		Creator:     strings.Join(paper.Authors, ", "),
+		PubDate:     paper.PubDate.Format(time.RFC1123Z),
+		GUID: GUID{
+			IsPermaLink: true,
+			Text:        paper.URL,
+		},
+	}
+
+	if paper.ArxivID != "" {
+		item.Categories = append(item.Categories, Category{Domain: categoryArxiv, Text: paper.ArxivID})
+	}
+	item.Categories = append(item.Categories,
+		Category{Domain: categoryUpvotes, Text: strconv.Itoa(paper.Upvotes)},
+		Category{Domain: categoryComments, Text: strconv.Itoa(paper.Comments)},
+	)
+	if paper.GitHubURL != "" {
+		item.Categories = append(item.Categories, Category{Domain: categoryGitHub, Text: paper.GitHubURL})
+	}
+	if paper.Comments > 0 {
+		item.Comments = paper.URL + "#community"
+	}
+	if paper.Thumbnail != "" {
+		item.Thumbnail = &MediaThumbnail{URL: paper.Thumbnail}
+	}
+	return item
+}
+
+// atomFeedID derives a stable feed id from the request URL, keeping only the
+// parameters that select a different feed rather than a different format.
+func atomFeedID(requestURL string) string {
+	u, err := url.Parse(requestURL)
 	if err != nil {
-		formattedDate = rss.Channel.LastBuildDate // Fallback to original format
-	} else {
-		formattedDate = parsedDate.Format("2006-01-02")
+		return requestURL
+	}
+	u.Path = strings.TrimSuffix(u.Path, ".atom")
+	query := url.Values{}
+	for _, key := range []string{"date", "lang", "view"} {
+		if value := u.Query().Get(key); value != "" {
+			query.Set(key, value)
+		}
 	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
 
-	// Create markdown
-	var markdown strings.Builder
+// rssToAtom converts a generated RSS feed into an equivalent Atom 1.0 feed.
+func rssToAtom(rssContent []byte, requestURL string) ([]byte, error) {
+	var rss RSS
+	if err := xml.Unmarshal(rssContent, &rss); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal RSS XML: %w", err)
+	}
 
-	markdown.WriteString(fmt.Sprintf("# %s\n\n", rss.Channel.Title))
-	markdown.WriteString(fmt.Sprintf("*%s*\n\n", rss.Channel.Description))
-	markdown.WriteString(fmt.Sprintf("*Last updated: %s*\n\n", formattedDate))
-	markdown.WriteString("---\n\n")
+	updated := atomTime(rss.Channel.LastBuildDate)
+	feed := AtomFeed{
+		ID:        atomFeedID(requestURL),
+		Title:     rss.Channel.Title,
+		Subtitle:  rss.Channel.Description,
+		Updated:   updated,
+		Author:    AtomPerson{Name: "Takara.ai"},
+		Generator: rss.Channel.Generator,
+		Links:     []AtomLink{{Href: requestURL, Rel: "self", Type: "application/atom+xml"}},
+	}
+	if rss.Channel.Link != "" {
+		feed.Links = append(feed.Links, AtomLink{Href: rss.Channel.Link, Rel: "alternate", Type: "text/html"})
+	}
 
-	// Process each item
 	for _, item := range rss.Channel.Items {
-		title := strings.ReplaceAll(item.Title, "\n", " ")
-		title = strings.TrimSpace(title)
-
-		markdown.WriteString(fmt.Sprintf("## [%s](%s)\n\n", title, item.Link))
-		markdown.WriteString(fmt.Sprintf("%s\n\n", item.Description.Text))
-		markdown.WriteString("---\n\n")
+		entry := AtomEntry{
+			ID:        item.GUID.Text,
+			Title:     strings.TrimSpace(item.Title),
+			Links:     []AtomLink{{Href: item.Link, Rel: "alternate", Type: "text/html"}},
+			Published: atomTime(item.PubDate),
+			Updated:   atomTime(item.PubDate),
+			Content:   AtomContent{Type: "html", Text: item.Description.Text},
+		}
+		// Atom ids must be IRIs, so non-permalink GUIDs get a tag: URI
+		if !item.GUID.IsPermaLink {
+			entry.ID = "tag:" + strings.TrimPrefix(liveURL, "https://") + ",2024:" + item.GUID.Text
+		}
+		if item.Creator != "" {
+			for _, name := range strings.Split(item.Creator, ", ") {
+				entry.Authors = append(entry.Authors, AtomPerson{Name: name})
+			}
+		}
+		for _, c := range item.Categories {
+			entry.Categories = append(entry.Categories, AtomCategory{Term: c.Text, Scheme: c.Domain})
+		}
+		if item.Comments != "" {
+			entry.Links = append(entry.Links, AtomLink{Href: item.Comments, Rel: "replies", Type: "text/html"})
+		}
+		feed.Entries = append(feed.Entries, entry)
 	}
 
-	// logger.Info("Markdown Generated", "markdown", markdown.String())
-	return markdown.String(), nil
+	output, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal Atom feed: %w", err)
+	}
+	return append([]byte(xml.Header), output...), nil
 }
 
-// summarizeWithLLM summarizes the markdown content using Hugging Face Router API
-// It now accepts a context for cancellation and timeout, and uses an HTTP client with a timeout.
-func summarizeWithLLM(ctx context.Context, markdownContent string) (string, error) {
-	apiURL := "https://router.huggingface.co/together/v1/chat/completions"
-	apiKey := os.Getenv("HF_API_KEY")
+// rssToJSONFeed converts a generated RSS feed into an equivalent JSON Feed 1.1 document.
+func rssToJSONFeed(rssContent []byte, requestURL string) ([]byte, error) {
+	var rss RSS
+	if err := xml.Unmarshal(rssContent, &rss); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal RSS XML: %w", err)
+	}
 
-	if apiKey == "" {
-		return "", fmt.Errorf("HF_API_KEY environment variable is not set")
+	feed := JSONFeed{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       rss.Channel.Title,
+		HomePageURL: rss.Channel.Link,
+		FeedURL:     requestURL,
+		Description: rss.Channel.Description,
+		Authors:     []JSONFeedAuthor{{Name: "Takara.ai"}},
+		Items:       make([]JSONFeedItem, 0, len(rss.Channel.Items)),
 	}
 
-	prompt := `Create a brief morning briefing on these AI research papers, written in a conversational style for busy professionals. Focus on what's new and what it means for businesses and society.
-Format the output in HTML:
-<h2>Morning Headline</h2>
-<p>(1 sentence)</p>
+	for _, item := range rss.Channel.Items {
+		entry := JSONFeedItem{
+			ID:            item.GUID.Text,
+			URL:           item.Link,
+			Title:         strings.TrimSpace(item.Title),
+			ContentHTML:   item.Description.Text,
+			DatePublished: atomTime(item.PubDate),
+		}
+		if item.Thumbnail != nil {
+			entry.Image = item.Thumbnail.URL
+		}
+		if item.Creator != "" {
+			for _, name := range strings.Split(item.Creator, ", ") {
+				entry.Authors = append(entry.Authors, JSONFeedAuthor{Name: name})
+			}
+		}
+		// Only paper items carry upvotes; summary items have no paper metadata
+		if upvotes := item.category(categoryUpvotes); upvotes != "" {
+			paper := &JSONFeedPaper{
+				About:     baseURL,
+				ArxivID:   item.category(categoryArxiv),
+				GitHubURL: item.category(categoryGitHub),
+			}
+			paper.Upvotes, _ = strconv.Atoi(upvotes)
+			paper.Comments, _ = strconv.Atoi(item.category(categoryComments))
+			if paper.ArxivID != "" {
+				entry.ExternalURL = "https://arxiv.org/abs/" + paper.ArxivID
+				entry.Tags = append(entry.Tags, "arxiv:"+paper.ArxivID)
+			}
+			entry.Paper = paper
+		}
+		feed.Items = append(feed.Items, entry)
+	}
 
-<h2>What's New</h2>
-<p>(2-3 sentences, written like you're explaining it to a friend over coffee, with citations to papers as <a href="link">Paper Name</a>)</p>
-<ul>
-  <li>Cover all papers in a natural, flowing narrative</li>
-  <li>Group related papers together</li>
-  <li>Include key metrics and outcomes</li>
-  <li>Keep the tone light and engaging</li>
-</ul>
+	output, err := json.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal JSON feed: %w", err)
+	}
+	return output, nil
+}
 
-Keep it under 200 words. Start with the most impressive or important paper. Focus on outcomes and implications, not technical details. Write like you're explaining it to a friend over coffee. Do not write a word count.
+// atomTime converts an RSS RFC 1123 date into the RFC 3339 form Atom requires.
+func atomTime(rssDate string) string {
+	t, err := time.Parse(time.RFC1123Z, rssDate)
+	if err != nil {
+		t = time.Now().UTC()
+	}
+	return t.Format(time.RFC3339)
+}
 
-Do not enclose the HTML in a markdown code block, just return the HTML.
+// feedFormat strips a format extension from the path (e.g. /api/feed.atom) and
+// returns the normalized path with the requested output format. Without an
+// extension or ?format=, the Accept header decides.
+func feedFormat(r *http.Request, path string) (string, string) {
+	for _, format := range []string{formatAtom, formatJSON} {
+		if strings.HasSuffix(path, "."+format) {
+			return strings.TrimSuffix(path, "."+format), format
+		}
+	}
+	switch r.URL.Query().Get("format") {
+	case formatAtom:
+		return path, formatAtom
+	case formatJSON:
+		return path, formatJSON
+	case formatRSS:
+		return path, formatRSS
+	}
 
-Below are the paper abstracts and information in markdown format:
-` + markdownContent
+	return path, acceptFeedFormat(r.Header.Get("Accept"))
+}
 
-	request := LLMRequest{
-		Model: "Qwen/Qwen2.5-72B-Instruct-Turbo",
-		Messages: []Message{
-			{
-				Role:    "user",
-				Content: prompt,
-			},
-		},
-		MaxTokens: 4096,
-		Stream:    false,
-		StreamOptions: struct {
-			IncludeUsage bool `json:"include_usage"`
-		}{
-			IncludeUsage: true,
-		},
-		Temperature:       0.6,
-		TopP:              0.95,
-		SeparateReasoning: true,
+// acceptFeedFormat picks the output format from an Accept header by q-value. Atom and
+// JSON Feed are only chosen when asked for by their exact media types and preferred
+// over RSS, which wildcards and generic XML types also accept, so clients sending
+// "application/json, */*" or listing Atom as a fallback keep getting RSS.
+func acceptFeedFormat(accept string) string {
+	q := map[string]float64{}
+	for _, part := range strings.Split(accept, ",") {
+		params := strings.Split(part, ";")
+		mediaType := strings.ToLower(strings.TrimSpace(params[0]))
+		weight := 1.0
+		for _, param := range params[1:] {
+			if value, ok := strings.CutPrefix(strings.TrimSpace(param), "q="); ok {
+				if w, err := strconv.ParseFloat(value, 64); err == nil {
+					weight = w
+				}
+			}
+		}
+
+		format := ""
+		switch mediaType {
+		case "application/rss+xml", "application/xml", "text/xml", "application/*", "text/*", "*/*":
+			format = formatRSS
+		case "application/atom+xml":
+			format = formatAtom
+		case "application/feed+json":
+			format = formatJSON
+		default:
+			continue
+		}
+		q[format] = max(q[format], weight)
 	}
 
-	requestBody, err := json.Marshal(request)
-	if err != nil {
-		return "", fmt.Errorf("failed to marshal LLM request: %w", err)
+	switch {
+	case q[formatAtom] > q[formatRSS] && q[formatAtom] >= q[formatJSON]:
+		return formatAtom
+	case q[formatJSON] > q[formatRSS] && q[formatJSON] > q[formatAtom]:
+		return formatJSON
 	}
+	return formatRSS
+}
 
-	// Create request with context
-	req, err := http.NewRequestWithContext(ctx, "POST", apiURL, bytes.NewBuffer(requestBody))
-	if err != nil {
-		return "", fmt.Errorf("failed to create LLM request: %w", err)
+// writeFeed writes a generated RSS feed in the requested output format.
+func writeFeed(w http.ResponseWriter, rssContent []byte, format string, requestURL string) {
+	// The format may be negotiated from the Accept header, so caches must key on it
+	w.Header().Set("Vary", "Accept")
+
+	switch format {
+	case formatAtom:
+		atom, err := rssToAtom(rssContent, requestURL)
+		if err != nil {
+			logger.Error("Failed to convert feed to Atom", "error", err)
+			http.Error(w, "Error generating feed", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/atom+xml")
+		w.Write(atom)
+	case formatJSON:
+		feed, err := rssToJSONFeed(rssContent, requestURL)
+		if err != nil {
+			logger.Error("Failed to convert feed to JSON Feed", "error", err)
+			http.Error(w, "Error generating feed", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/feed+json")
+		w.Write(feed)
+	default:
+		w.Header().Set("Content-Type", "application/rss+xml")
+		w.Write(rssContent)
 	}
+}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+apiKey)
+// paperDescription renders the item description, putting the TL;DR (when generated)
+// above the abstract.
+func paperDescription(paper Paper) string {
+	if paper.TLDR == "" {
+		return paper.Abstract
+	}
+	var description strings.Builder
+	description.WriteString("<p><strong>TL;DR:</strong> " + html.EscapeString(paper.TLDR) + "</p>")
+	if paper.WhyItMatters != "" {
+		description.WriteString("<p><strong>Why it matters:</strong> " + html.EscapeString(paper.WhyItMatters) + "</p>")
+	}
+	if paper.Abstract != "" {
+		description.WriteString("<p>" + html.EscapeString(paper.Abstract) + "</p>")
+	}
+	return description.String()
+}
 
-	// Create an HTTP client with the LLM timeout
-	client := &http.Client{
-		Timeout: llmTimeout,
+// splitPaperDescription reverses paperDescription, recovering the TL;DR, why it matters
+// and abstract from a feed item's description.
+func splitPaperDescription(description string) (tldr, whyItMatters, abstract string) {
+	if !strings.HasPrefix(description, "<p><strong>TL;DR:</strong> ") {
+		return "", "", description
 	}
-	resp, err := client.Do(req)
+	nodes, err := html.ParseFragment(strings.NewReader(description), &html.Node{
+		Type:     html.ElementNode,
+		Data:     "body",
+		DataAtom: atom.Body,
+	})
 	if err != nil {
-		if errors.Is(err, context.DeadlineExceeded) {
-			return "", fmt.Errorf("timeout calling Hugging Face Router API: %w", err)
+		return "", "", description
+	}
+	for _, n := range nodes {
+		text := extractText(n)
+		if after, ok := strings.CutPrefix(text, "TL;DR: "); ok {
+			tldr = after
+		} else if after, ok := strings.CutPrefix(text, "Why it matters: "); ok {
+			whyItMatters = after
+		} else {
+			abstract = text
 		}
-		return "", fmt.Errorf("failed to send request to Hugging Face Router API: %w", err)
 	}
-	defer resp.Body.Close()
+	return tldr, whyItMatters, abstract
+}
 
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("HTTP error %d from Hugging Face Router API: %s", resp.StatusCode, string(bodyBytes))
-	}
+// Simple CORS middleware
+func corsMiddleware(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
 
-	var llmResp LLMResponse
-	if err := json.NewDecoder(resp.Body).Decode(&llmResp); err != nil {
-		return "", fmt.Errorf("failed to decode LLM response: %w", err)
+		if r.Method == "OPTIONS" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		next(w, r)
 	}
+}
 
-	if len(llmResp.Choices) == 0 || llmResp.Choices[0].Message.Content == "" {
-		logger.Warn("LLM response contained no choices or empty content", "response", llmResp)
-		return "", fmt.Errorf("no valid response content returned from Hugging Face Router API")
+// Store keeps a permanent, date-keyed archive of generated artifacts so history
+// survives cache expiry. Dates use the YYYY-MM-DD layout.
+type Store interface {
+	// List returns the archived dates for a kind, newest first.
+	List(ctx context.Context, kind string) ([]string, error)
+	// Get returns errNotArchived if nothing is stored for the date.
+	Get(ctx context.Context, kind, date string) ([]byte, error)
+	Put(ctx context.Context, kind, date string, data []byte) error
+}
+
+// Kinds of archived artifacts
+const (
+	kindPapers        = "papers"
+	kindSummaries     = "summaries"
+	kindConversations = "conversations"
+	kindEpisodes      = "episodes"
+)
+
+var archiveKinds = []string{kindPapers, kindSummaries, kindConversations, kindEpisodes}
+
+var errNotArchived = errors.New("not archived")
+
+// summaryArchiveKind archives summaries in other languages under their own kind,
+// e.g. summaries-de, so each language keeps its own history.
+func summaryArchiveKind(lang string) string {
+	if lang == defaultSummaryLang {
+		return kindSummaries
 	}
+	return kindSummaries + "-" + lang
+}
 
-	response := llmResp.Choices[0].Message.Content
+// blobArchive archives artifacts in a BlobStore under <prefix><kind>/<date>. Over a
+// fsBlobStore with no prefix this is the dir/<kind>/<date> layout.
+type blobArchive struct {
+	blobs  BlobStore
+	prefix string
+}
 
-	// Extract only the content after <think> tags if present
-	if strings.Contains(response, "<think>") {
-		parts := strings.Split(response, "</think>")
+func (s *blobArchive) key(kind, date string) string {
+	return s.prefix + kind + "/" + date
+}
+
+func (s *blobArchive) List(ctx context.Context, kind string) ([]string, error) {
+	dir := s.key(kind, "")
+	keys, err := s.blobs.List(ctx, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s archive: %w", kind, err)
+	}
+
+	var dates []string
+	for _, key := range keys {
+		if date := strings.TrimPrefix(key, dir); !strings.Contains(date, "/") {
+			dates = append(dates, date)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(dates)))
+	return dates, nil
+}
+
+func (s *blobArchive) Get(ctx context.Context, kind, date string) ([]byte, error) {
+	data, _, err := s.blobs.Get(ctx, s.key(kind, date))
+	if errors.Is(err, errBlobNotFound) {
+		return nil, errNotArchived
+	}
+	return data, err
+}
+
+func (s *blobArchive) Put(ctx context.Context, kind, date string, data []byte) error {
+	return s.blobs.Put(ctx, s.key(kind, date), "", data)
+}
+
+// redisStore archives artifacts in Redis without expiry, indexing dates in a sorted set per kind.
+type redisStore struct {
+	client *redis.Client
+}
+
+func (s *redisStore) key(kind, date string) string {
+	return archiveKeyPrefix + kind + ":" + date
+}
+
+func (s *redisStore) indexKey(kind string) string {
+	return archiveKeyPrefix + kind
+}
+
+func (s *redisStore) List(ctx context.Context, kind string) ([]string, error) {
+	dates, err := s.client.ZRevRange(ctx, s.indexKey(kind), 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s archive: %w", kind, err)
+	}
+	return dates, nil
+}
+
+func (s *redisStore) Get(ctx context.Context, kind, date string) ([]byte, error) {
+	data, err := s.client.Get(ctx, s.key(kind, date)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, errNotArchived
+	}
+	return data, err
+}
+
+func (s *redisStore) Put(ctx context.Context, kind, date string, data []byte) error {
+	day, err := time.Parse(dateLayout, date)
+	if err != nil {
+		return fmt.Errorf("invalid archive date %q: %w", date, err)
+	}
+	_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, s.key(kind, date), data, 0)
+		pipe.ZAdd(ctx, s.indexKey(kind), redis.Z{Score: float64(day.Unix()), Member: date})
+		return nil
+	})
+	return err
+}
+
+// initArchive picks the archive backend: a local directory when ARCHIVE_DIR is set,
+// otherwise the blob store when configured, otherwise Redis when connected. The blob
+// store wins over Redis since history grows without bound and Redis is sized as a
+// cache. Without any of them, nothing is archived.
+func initArchive() {
+	if dir := os.Getenv("ARCHIVE_DIR"); dir != "" {
+		archive = &blobArchive{blobs: &fsBlobStore{dir: dir}}
+		logger.Info("Using file archive", "dir", dir)
+		return
+	}
+	if blobs != nil {
+		archive = &blobArchive{blobs: blobs, prefix: archiveBlobPrefix}
+		logger.Info("Using blob store archive")
+		return
+	}
+	if redisConnected {
+		archive = &redisStore{client: rdb}
+		logger.Info("Using Redis archive")
+		return
+	}
+	logger.Warn("No archive configured, history will not survive cache expiry")
+}
+
+// archiveDate maps a listing date to its archive date; today's listing is archived under today.
+func archiveDate(date string) string {
+	if date == "" {
+		return time.Now().UTC().Format(dateLayout)
+	}
+	return date
+}
+
+// scheduledUpdateKey marks the context of the scheduled cache update
+type scheduledUpdateKey struct{}
+
+// withScheduledUpdate marks ctx as the scheduled cache update, the only writer allowed
+// to archive today's listing.
+func withScheduledUpdate(ctx context.Context) context.Context {
+	return context.WithValue(ctx, scheduledUpdateKey{}, true)
+}
+
+func isScheduledUpdate(ctx context.Context) bool {
+	scheduled, _ := ctx.Value(scheduledUpdateKey{}).(bool)
+	return scheduled
+}
+
+// archiveGet reads an artifact from the archive, returning errNotArchived if there is none.
+// Today's listing is still changing, so it is never served from the archive.
+func archiveGet(ctx context.Context, kind, date string) ([]byte, error) {
+	if archive == nil || date == "" || isToday(date) {
+		return nil, errNotArchived
+	}
+	return archive.Get(ctx, kind, date)
+}
+
+// archivePut stores an artifact in the archive. Failures are logged, not returned,
+// since the archive must never block serving a freshly generated artifact. Today's
+// artifacts are only archived by the scheduled update, so a snapshot taken by an early
+// reader never stands in for the day.
+func archivePut(ctx context.Context, kind, date string, data []byte) {
+	if archive == nil {
+		return
+	}
+	if (date == "" || isToday(date)) && !isScheduledUpdate(ctx) {
+		return
+	}
+	date = archiveDate(date)
+	if err := archive.Put(ctx, kind, date, data); err != nil {
+		logger.Warn("Failed to archive artifact", "kind", kind, "date", date, "error", err)
+		return
+	}
+	logger.Info("Archived artifact", "kind", kind, "date", date)
+}
+
+func initRedis() {
+	redisURL := os.Getenv("KV_URL")
+	if redisURL == "" {
+		return
+	}
+
+	opt, err := redis.ParseURL(redisURL)
+	if err != nil {
+		logger.Error("Error parsing Redis URL", "error", err)
+		return
+	}
+
+	rdb = redis.NewClient(opt)
+	pingErr := rdb.Ping(ctx).Err()
+	if pingErr != nil {
+		logger.Error("Error connecting to Redis", "error", pingErr)
+		return
+	}
+
+	redisConnected = true
+	logger.Info("Successfully connected to Redis")
+}
+
+// BlobStore keeps binary objects such as podcast audio, episode timings and the
+// podcast manifest. Keys are slash-separated paths.
+type BlobStore interface {
+	// Put stores data under key, replacing any existing object. An empty
+	// contentType leaves it to the backend.
+	Put(ctx context.Context, key, contentType string, data []byte) error
+	// PutIfMatch stores data under key only if the stored object still has the
+	// given ETag, or if there is no object yet when etag is empty. Otherwise it
+	// returns errBlobConflict.
+	PutIfMatch(ctx context.Context, key, contentType string, data []byte, etag string) error
+	// Get returns errBlobNotFound if nothing is stored under key.
+	Get(ctx context.Context, key string) ([]byte, BlobInfo, error)
+	// Open streams key from byte offset to its end, so large objects can be served
+	// in ranges. It returns errBlobNotFound if nothing is stored under key.
+	Open(ctx context.Context, key string, offset int64) (io.ReadCloser, error)
+	// Stat returns errBlobNotFound if nothing is stored under key.
+	Stat(ctx context.Context, key string) (BlobInfo, error)
+	// List returns the keys starting with prefix.
+	List(ctx context.Context, prefix string) ([]string, error)
+	// Delete removes key. Deleting a missing key is not an error.
+	Delete(ctx context.Context, key string) error
+	// SignedURL returns a URL clients can download key from without going through
+	// the API, or errNoBlobURL if the backend can't serve clients itself.
+	SignedURL(ctx context.Context, key string) (string, error)
+	// PublicURL returns a permanent URL for key, or errNoBlobURL if objects
+	// aren't publicly reachable.
+	PublicURL(key string) (string, error)
+}
+
+// BlobInfo describes a stored object.
+type BlobInfo struct {
+	Size    int64
+	ModTime time.Time
+	ETag    string
+}
+
+var (
+	errBlobNotFound = errors.New("blob not found")
+	errBlobConflict = errors.New("blob changed since it was read")
+	errNoBlobURL    = errors.New("blob store has no direct URLs")
+)
+
+// fsBlobStore keeps objects as files under dir, for local development and
+// self-hosting without Cloudflare.
+type fsBlobStore struct {
+	dir string
+	// mu makes conditional puts atomic within this process
+	mu sync.Mutex
+}
+
+func (s *fsBlobStore) path(key string) (string, error) {
+	if !filepath.IsLocal(filepath.FromSlash(key)) {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
+
+func (s *fsBlobStore) Put(ctx context.Context, key, contentType string, data []byte) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return writeFileAtomic(path, data)
+}
+
+func (s *fsBlobStore) PutIfMatch(ctx context.Context, key, contentType string, data []byte, etag string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	info, err := s.Stat(ctx, key)
+	if errors.Is(err, errBlobNotFound) {
+		info = BlobInfo{}
+	} else if err != nil {
+		return err
+	}
+	if info.ETag != etag {
+		return errBlobConflict
+	}
+	return writeFileAtomic(path, data)
+}
+
+// writeFileAtomic writes to a temp file and renames it so readers never see a partial file.
+func writeFileAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	tmp, err := os.CreateTemp(dir, ".tmp-"+filepath.Base(path))
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s *fsBlobStore) Get(ctx context.Context, key string) ([]byte, BlobInfo, error) {
+	info, err := s.Stat(ctx, key)
+	if err != nil {
+		return nil, BlobInfo{}, err
+	}
+	path, _ := s.path(key)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, BlobInfo{}, errBlobNotFound
+	} else if err != nil {
+		return nil, BlobInfo{}, err
+	}
+	info.Size = int64(len(data))
+	return data, info, nil
+}
+
+func (s *fsBlobStore) Open(ctx context.Context, key string, offset int64) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, errBlobNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return f, nil
+}
+
+func (s *fsBlobStore) Stat(ctx context.Context, key string) (BlobInfo, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return BlobInfo{}, err
+	}
+	fi, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) || (err == nil && fi.IsDir()) {
+		return BlobInfo{}, errBlobNotFound
+	} else if err != nil {
+		return BlobInfo{}, err
+	}
+	// Every write renames a new file into place, so size and modification time
+	// identify a version
+	etag := fmt.Sprintf(`"%x-%x"`, fi.ModTime().UnixNano(), fi.Size())
+	return BlobInfo{Size: fi.Size(), ModTime: fi.ModTime().UTC(), ETag: etag}, nil
+}
+
+func (s *fsBlobStore) List(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+	err := filepath.WalkDir(s.dir, func(path string, entry fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			return nil
+		}
+		rel, err := filepath.Rel(s.dir, path)
+		if err != nil {
+			return err
+		}
+		if key := filepath.ToSlash(rel); strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", s.dir, err)
+	}
+	return keys, nil
+}
+
+func (s *fsBlobStore) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// SignedURL always fails: local files are only reachable through the API.
+func (s *fsBlobStore) SignedURL(ctx context.Context, key string) (string, error) {
+	return "", errNoBlobURL
+}
+
+func (s *fsBlobStore) PublicURL(key string) (string, error) {
+	return "", errNoBlobURL
+}
+
+// s3BlobStore keeps objects in a private Cloudflare R2 (or other S3-compatible) bucket.
+type s3BlobStore struct {
+	client *s3.Client
+	bucket string
+	// publicURL is the r2.dev or custom domain of a public bucket, if any
+	publicURL string
+}
+
+func newS3BlobStore(endpoint, accessKey, secretKey, bucket string) (*s3BlobStore, error) {
+	cfg, err := config.LoadDefaultConfig(context.TODO(),
+		config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(accessKey, secretKey, "")),
+		config.WithRegion("auto"),
+		config.WithEndpointResolverWithOptions(aws.EndpointResolverWithOptionsFunc(
+			func(service, region string, options ...interface{}) (aws.Endpoint, error) {
+				return aws.Endpoint{URL: endpoint, SigningRegion: "auto"}, nil
+			},
+		)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &s3BlobStore{
+		client: s3.NewFromConfig(cfg),
+		bucket: bucket,
+		// R2_ENDPOINT is the S3 API, which never serves public objects. Public buckets are
+		// reached through their r2.dev or custom domain instead.
+		publicURL: strings.TrimSuffix(os.Getenv("R2_PUBLIC_URL"), "/"),
+	}, nil
+}
+
+// isS3NotFound reports whether err means the object doesn't exist. GetObject
+// returns NoSuchKey, while HeadObject has no body to carry it and returns NotFound.
+func isS3NotFound(err error) bool {
+	var noSuchKey *types.NoSuchKey
+	var notFound *types.NotFound
+	return errors.As(err, &noSuchKey) || errors.As(err, &notFound)
+}
+
+// isS3Conflict reports whether a conditional request failed because the object
+// changed: 412 when the precondition no longer holds, 409 when another
+// conditional write to the same key was in flight.
+func isS3Conflict(err error) bool {
+	var status interface{ HTTPStatusCode() int }
+	if !errors.As(err, &status) {
+		return false
+	}
+	return status.HTTPStatusCode() == http.StatusPreconditionFailed || status.HTTPStatusCode() == http.StatusConflict
+}
+
+func (s *s3BlobStore) Put(ctx context.Context, key, contentType string, data []byte) error {
+	return s.put(ctx, s.putInput(key, contentType, data), len(data))
+}
+
+func (s *s3BlobStore) PutIfMatch(ctx context.Context, key, contentType string, data []byte, etag string) error {
+	input := s.putInput(key, contentType, data)
+	if etag == "" {
+		input.IfNoneMatch = aws.String("*")
+	} else {
+		input.IfMatch = aws.String(etag)
+	}
+	return s.put(ctx, input, len(data))
+}
+
+func (s *s3BlobStore) putInput(key, contentType string, data []byte) *s3.PutObjectInput {
+	input := &s3.PutObjectInput{
+		Bucket: &s.bucket,
+		Key:    &key,
+		Body:   bytes.NewReader(data),
+	}
+	if contentType != "" {
+		input.ContentType = aws.String(contentType)
+	}
+	return input
+}
+
+func (s *s3BlobStore) put(ctx context.Context, input *s3.PutObjectInput, size int) error {
+	key := aws.ToString(input.Key)
+	logger.Info("Uploading object to R2", "key", key, "size", size)
+	_, err := s.client.PutObject(ctx, input)
+	if isS3Conflict(err) {
+		return errBlobConflict
+	} else if err != nil {
+		return fmt.Errorf("failed to upload %s to R2: %w", key, err)
+	}
+	logger.Info("Successfully uploaded object to R2", "key", key, "size", size)
+	return nil
+}
+
+func (s *s3BlobStore) Get(ctx context.Context, key string) ([]byte, BlobInfo, error) {
+	resp, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: &s.bucket,
+		Key:    &key,
+	})
+	if isS3NotFound(err) {
+		return nil, BlobInfo{}, errBlobNotFound
+	} else if err != nil {
+		return nil, BlobInfo{}, fmt.Errorf("failed to get %s from R2: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, BlobInfo{}, fmt.Errorf("failed to read %s from R2: %w", key, err)
+	}
+	return data, BlobInfo{Size: int64(len(data)), ModTime: aws.ToTime(resp.LastModified), ETag: aws.ToString(resp.ETag)}, nil
+}
+
+func (s *s3BlobStore) Open(ctx context.Context, key string, offset int64) (io.ReadCloser, error) {
+	resp, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: &s.bucket,
+		Key:    &key,
+		Range:  aws.String(fmt.Sprintf("bytes=%d-", offset)),
+	})
+	if isS3NotFound(err) {
+		return nil, errBlobNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get %s from R2: %w", key, err)
+	}
+	return resp.Body, nil
+}
+
+func (s *s3BlobStore) Stat(ctx context.Context, key string) (BlobInfo, error) {
+	resp, err := s.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: &s.bucket,
+		Key:    &key,
+	})
+	if isS3NotFound(err) {
+		return BlobInfo{}, errBlobNotFound
+	} else if err != nil {
+		return BlobInfo{}, fmt.Errorf("failed to stat %s in R2: %w", key, err)
+	}
+	return BlobInfo{
+		Size:    aws.ToInt64(resp.ContentLength),
+		ModTime: aws.ToTime(resp.LastModified),
+		ETag:    aws.ToString(resp.ETag),
+	}, nil
+}
+
+func (s *s3BlobStore) List(ctx context.Context, prefix string) ([]string, error) {
+	var keys []string
+	paginator := s3.NewListObjectsV2Paginator(s.client, &s3.ListObjectsV2Input{
+		Bucket: &s.bucket,
+		Prefix: &prefix,
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s in R2: %w", prefix, err)
+		}
+		for _, object := range page.Contents {
+			keys = append(keys, aws.ToString(object.Key))
+		}
+	}
+	return keys, nil
+}
+
+func (s *s3BlobStore) Delete(ctx context.Context, key string) error {
+	_, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: &s.bucket,
+		Key:    &key,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete %s from R2: %w", key, err)
+	}
+	return nil
+}
+
+// SignedURL returns a permanent URL under R2_PUBLIC_URL for public buckets,
+// otherwise a presigned URL that expires after R2_PRESIGN_EXPIRY.
+func (s *s3BlobStore) SignedURL(ctx context.Context, key string) (string, error) {
+	if location, err := s.PublicURL(key); err == nil {
+		return location, nil
+	}
+	req, err := s3.NewPresignClient(s.client).PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: &s.bucket,
+		Key:    &key,
+	}, s3.WithPresignExpires(r2PresignExpiry()))
+	if err != nil {
+		return "", fmt.Errorf("failed to presign %s: %w", key, err)
+	}
+	return req.URL, nil
+}
+
+// PublicURL returns the object's URL under R2_PUBLIC_URL, if the bucket is public.
+func (s *s3BlobStore) PublicURL(key string) (string, error) {
+	if s.publicURL == "" {
+		return "", errNoBlobURL
+	}
+	return s.publicURL + "/" + key, nil
+}
+
+// initBlobs picks where podcast audio and timings are stored: a local directory
+// when BLOB_DIR is set, otherwise Cloudflare R2 when its env vars are set. Without
+// either, episodes are not stored and every request generates audio.
+func initBlobs() {
+	if dir := os.Getenv("BLOB_DIR"); dir != "" {
+		blobs = &fsBlobStore{dir: dir}
+		logger.Info("Using file blob store", "dir", dir)
+		return
+	}
+
+	endpoint := os.Getenv("R2_ENDPOINT")
+	accessKey := os.Getenv("R2_ACCESS_KEY_ID")
+	secretKey := os.Getenv("R2_SECRET_ACCESS_KEY")
+	bucket := os.Getenv("R2_BUCKET_NAME")
+	if endpoint == "" || accessKey == "" || secretKey == "" || bucket == "" {
+		logger.Warn("No blob store configured (BLOB_DIR or R2 env vars), audio podcast will not be stored")
+		return
+	}
+	store, err := newS3BlobStore(endpoint, accessKey, secretKey, bucket)
+	if err != nil {
+		logger.Error("Failed to init R2 S3 client", "error", err)
+		return
+	}
+	blobs = store
+	logger.Info("Cloudflare R2 S3 client initialized")
+}
+
+// blobURL returns a URL for downloading an object straight from the blob store.
+func blobURL(ctx context.Context, key string) (string, error) {
+	if blobs == nil {
+		return "", errNoBlobURL
+	}
+	return blobs.SignedURL(ctx, key)
+}
+
+// r2PresignExpiry reads R2_PRESIGN_EXPIRY (e.g. "6h"), within what SigV4 allows.
+func r2PresignExpiry() time.Duration {
+	raw := os.Getenv("R2_PRESIGN_EXPIRY")
+	if raw == "" {
+		return defaultPresignExpiry
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil || d <= 0 {
+		logger.Warn("Invalid R2_PRESIGN_EXPIRY, using default", "value", raw, "default", defaultPresignExpiry)
+		return defaultPresignExpiry
+	}
+	if d > maxPresignExpiry {
+		logger.Warn("R2_PRESIGN_EXPIRY exceeds the SigV4 limit, capping it", "value", raw, "max", maxPresignExpiry)
+		return maxPresignExpiry
+	}
+	return d
+}
+
+// podcastDirectDelivery reports whether archived episodes are downloaded from the
+// blob store directly, both from /api/podcast and from feed enclosures.
+func podcastDirectDelivery() bool {
+	return blobs != nil && os.Getenv("PODCAST_REDIRECT") == "true"
+}
+
+// PodcastManifest lists the archived podcast episodes in the blob store.
+// Latest points at the date of the newest episode and replaces a fixed "latest" object.
+type PodcastManifest struct {
+	Latest   string           `json:"latest"`
+	Episodes []PodcastEpisode `json:"episodes"`
+}
+
+type PodcastEpisode struct {
+	Date            string   `json:"date"`
+	Key             string   `json:"key"`
+	Size            int64    `json:"size"`
+	DurationSeconds int      `json:"duration_seconds"`
+	PaperURLs       []string `json:"paper_urls"`
+	CreatedAt       string   `json:"created_at"`
+	// Timings is the blob key of the line timings behind the transcripts and chapters
+	Timings string `json:"timings,omitempty"`
+}
+
+// podcastKey returns the blob key of the archived episode for a date.
+func podcastKey(date string) string {
+	return "podcast-" + date + ".mp3"
+}
+
+// podcastTimingsKey returns the blob key of the line timings for a date's episode.
+func podcastTimingsKey(date string) string {
+	return "podcast-" + date + ".json"
+}
+
+// podcastEpisodeKeyPattern matches the key of a dated episode object
+var podcastEpisodeKeyPattern = regexp.MustCompile(`^podcast-(\d{4}-\d{2}-\d{2})\.mp3$`)
+
+// getManifest loads the podcast manifest. The first time, when there is no manifest
+// yet, it is seeded from the episodes already in the blob store and written back.
+func getManifest(ctx context.Context) (*PodcastManifest, error) {
+	manifest, etag, err := readManifest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if etag == "" && len(manifest.Episodes) > 0 {
+		if err := putManifest(ctx, manifest, ""); err != nil && !errors.Is(err, errBlobConflict) {
+			logger.Warn("Failed to store seeded podcast manifest", "error", err)
+		}
+	}
+	return manifest, nil
+}
+
+// readManifest loads the podcast manifest along with the ETag to write it back
+// against. The ETag is empty if no manifest exists yet, in which case one is seeded
+// from the existing episodes.
+func readManifest(ctx context.Context) (*PodcastManifest, string, error) {
+	if blobs == nil {
+		return nil, "", fmt.Errorf("blob store not configured")
+	}
+	data, info, err := blobs.Get(ctx, podcastManifestKey)
+	if errors.Is(err, errBlobNotFound) {
+		manifest, err := seedManifest(ctx)
+		return manifest, "", err
+	} else if err != nil {
+		return nil, "", fmt.Errorf("failed to get podcast manifest: %w", err)
+	}
+
+	var manifest PodcastManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, "", fmt.Errorf("failed to decode podcast manifest: %w", err)
+	}
+	return &manifest, info.ETag, nil
+}
+
+// seedManifest rebuilds a manifest from episodes stored before there was one: dated
+// podcast-YYYY-MM-DD.mp3 objects, and the fixed podcast-latest.mp3 if no dated copy
+// of it exists. Durations are estimated from the size.
+func seedManifest(ctx context.Context) (*PodcastManifest, error) {
+	keys, err := blobs.List(ctx, "podcast-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list podcast episodes: %w", err)
+	}
+	stored := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		stored[key] = true
+	}
+
+	manifest := &PodcastManifest{}
+	seed := func(date, key string) {
+		info, err := blobs.Stat(ctx, key)
+		if err != nil {
+			logger.Warn("Skipping podcast episode while seeding manifest", "key", key, "error", err)
+			return
+		}
+		episode := PodcastEpisode{
+			Date:            date,
+			Key:             key,
+			Size:            info.Size,
+			DurationSeconds: int(info.Size * 8 / podcastBitrate),
+			CreatedAt:       info.ModTime.UTC().Format(time.RFC3339),
+		}
+		if stored[podcastTimingsKey(date)] {
+			episode.Timings = podcastTimingsKey(date)
+		}
+		manifest.Episodes = append(manifest.Episodes, episode)
+	}
+	for _, key := range keys {
+		if match := podcastEpisodeKeyPattern.FindStringSubmatch(key); match != nil {
+			seed(match[1], key)
+		}
+	}
+	if stored[podcastLatestKey] {
+		if info, err := blobs.Stat(ctx, podcastLatestKey); err == nil {
+			if date := info.ModTime.UTC().Format(dateLayout); !stored[podcastKey(date)] {
+				seed(date, podcastLatestKey)
+			}
+		}
+	}
+	if len(manifest.Episodes) == 0 {
+		return manifest, nil
+	}
+
+	sort.Slice(manifest.Episodes, func(i, j int) bool {
+		return manifest.Episodes[i].Date > manifest.Episodes[j].Date
+	})
+	manifest.Latest = manifest.Episodes[0].Date
+	logger.Info("Seeded podcast manifest from stored episodes", "episodes", len(manifest.Episodes), "latest", manifest.Latest)
+	return manifest, nil
+}
+
+// putManifest writes the manifest if it still has the ETag it was read with,
+// returning errBlobConflict if someone else wrote it in between.
+func putManifest(ctx context.Context, manifest *PodcastManifest, etag string) error {
+	if blobs == nil {
+		return fmt.Errorf("blob store not configured")
+	}
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal podcast manifest: %w", err)
+	}
+	if err := blobs.PutIfMatch(ctx, podcastManifestKey, "application/json", data, etag); err != nil {
+		return fmt.Errorf("failed to upload podcast manifest: %w", err)
+	}
+	return nil
+}
+
+// updateManifest applies change to the current manifest and writes it back, reading
+// it again and reapplying change whenever another writer got there first, so that
+// concurrent archivers never drop each other's episodes.
+func updateManifest(ctx context.Context, change func(*PodcastManifest)) (*PodcastManifest, error) {
+	for attempt := 1; ; attempt++ {
+		manifest, etag, err := readManifest(ctx)
+		if err != nil {
+			return nil, err
+		}
+		change(manifest)
+		err = putManifest(ctx, manifest, etag)
+		if err == nil {
+			return manifest, nil
+		}
+		if !errors.Is(err, errBlobConflict) || attempt == manifestMaxAttempts {
+			return nil, err
+		}
+		logger.Info("Podcast manifest changed concurrently, retrying", "attempt", attempt)
+	}
+}
+
+// archivePodcast stores an episode under its date key, records it in the manifest,
+// moves the latest pointer forward and prunes episodes past the retention period.
+func archivePodcast(ctx context.Context, date string, audioData []byte, timings []SegmentTiming, paperURLs []string) error {
+	if blobs == nil {
+		return fmt.Errorf("blob store not configured")
+	}
+
+	key := podcastKey(date)
+	if err := blobs.Put(ctx, key, "audio/mpeg", audioData); err != nil {
+		return fmt.Errorf("failed to store podcast: %w", err)
+	}
+
+	// Count frames for the exact duration, estimating only if the audio can't be parsed
+	duration := int(mp3Duration(audioData).Round(time.Second).Seconds())
+	if duration == 0 {
+		duration = int(int64(len(audioData)) * 8 / podcastBitrate)
+	}
+
+	episode := PodcastEpisode{
+		Date:            date,
+		Key:             key,
+		Size:            int64(len(audioData)),
+		DurationSeconds: duration,
+		PaperURLs:       paperURLs,
+		CreatedAt:       time.Now().UTC().Format(time.RFC3339),
+	}
+
+	// Transcripts and chapters are rendered from the timings on request
+	if len(timings) > 0 {
+		data, err := json.Marshal(timings)
+		if err == nil {
+			err = blobs.Put(ctx, podcastTimingsKey(date), "application/json", data)
+		}
+		if err != nil {
+			logger.Warn("Failed to upload episode timings", "date", date, "error", err)
+		} else {
+			episode.Timings = podcastTimingsKey(date)
+		}
+	}
+
+	retention := time.Duration(envInt("PODCAST_RETENTION_DAYS", 0)) * 24 * time.Hour
+	var expired []PodcastEpisode
+	manifest, err := updateManifest(ctx, func(manifest *PodcastManifest) {
+		// Replace any earlier episode for the same day
+		episodes := manifest.Episodes[:0]
+		for _, e := range manifest.Episodes {
+			if e.Date != date {
+				episodes = append(episodes, e)
+			}
+		}
+		manifest.Episodes = append(episodes, episode)
+		sort.Slice(manifest.Episodes, func(i, j int) bool {
+			return manifest.Episodes[i].Date > manifest.Episodes[j].Date
+		})
+
+		expired = pruneEpisodes(manifest, retention)
+		if len(manifest.Episodes) > 0 {
+			manifest.Latest = manifest.Episodes[0].Date
+		}
+	})
+	if err != nil {
+		return err
+	}
+	// Only delete once the manifest no longer lists them
+	deleteEpisodes(ctx, expired)
+
+	if data, err := json.Marshal(episode); err == nil {
+		archivePut(ctx, kindEpisodes, date, data)
+	}
+	logger.Info("Archived podcast episode", "key", key, "size", len(audioData), "latest", manifest.Latest)
+	return nil
+}
+
+// pruneEpisodes removes episodes older than retention from the manifest and returns
+// them. A zero retention keeps every episode.
+func pruneEpisodes(manifest *PodcastManifest, retention time.Duration) []PodcastEpisode {
+	if retention <= 0 {
+		return nil
+	}
+	cutoff := time.Now().UTC().Add(-retention).Format(dateLayout)
+
+	var expired []PodcastEpisode
+	kept := manifest.Episodes[:0]
+	for _, episode := range manifest.Episodes {
+		if episode.Date >= cutoff {
+			kept = append(kept, episode)
+		} else {
+			expired = append(expired, episode)
+		}
+	}
+	manifest.Episodes = kept
+	return expired
+}
+
+// deleteEpisodes deletes the audio and timings of episodes pruned from the manifest.
+// Objects that fail to delete are only logged, since nothing links to them anymore.
+func deleteEpisodes(ctx context.Context, episodes []PodcastEpisode) {
+	for _, episode := range episodes {
+		for _, key := range []string{episode.Key, episode.Timings} {
+			if key == "" {
+				continue
+			}
+			if err := blobs.Delete(ctx, key); err != nil {
+				logger.Warn("Failed to delete expired podcast object", "key", key, "error", err)
+			}
+		}
+		logger.Info("Deleted expired podcast episode", "key", episode.Key, "date", episode.Date)
+	}
+}
+
+// paperURLsFromFeed lists the paper links in a generated feed.
+func paperURLsFromFeed(feed []byte) []string {
+	papers, err := papersFromRSS(feed)
+	if err != nil {
+		logger.Warn("Failed to read paper URLs from feed", "error", err)
+		return nil
+	}
+	urls := make([]string, len(papers))
+	for i, paper := range papers {
+		urls[i] = paper.URL
+	}
+	return urls
+}
+
+// generatePodcastRSS builds a subscribable podcast feed with one item per stored episode.
+// baseRequestURL is the scheme and host the feed is served from.
+func generatePodcastRSS(episodes []PodcastEpisode, requestURL, baseRequestURL string) ([]byte, error) {
+	if len(episodes) > maxPodcastEpisodes {
+		episodes = episodes[:maxPodcastEpisodes]
+	}
+
+	items := make([]Item, 0, len(episodes))
+	for _, episode := range episodes {
+		day := listingTime(episode.Date)
+		// Podcast apps keep enclosure URLs for good, so only permanent public URLs may
+		// replace the API; presigned ones would expire in subscribers' libraries
+		enclosureURL := baseRequestURL + "/api/podcast?date=" + episode.Date
+		if podcastDirectDelivery() {
+			if location, err := blobs.PublicURL(episode.Key); err == nil {
+				enclosureURL = location
+			}
+		}
+		items = append(items, Item{
+			Title:       "Daily Papers for " + day.Format("January 2, 2006"),
+			Link:        liveURL,
+			Description: CDATA{Text: fmt.Sprintf("A conversation about %d AI research papers trending on Hugging Face on %s.", len(episode.PaperURLs), day.Format("January 2, 2006"))},
+			PubDate:     day.Format(time.RFC1123Z),
+			GUID: GUID{
+				IsPermaLink: false,
+				Text:        "podcast-" + episode.Date,
+			},
+			Enclosure: &Enclosure{
+				URL:    enclosureURL,
+				Length: episode.Size,
+				Type:   "audio/mpeg",
+			},
+			ItunesDuration:    formatDuration(time.Duration(episode.DurationSeconds) * time.Second),
+			ItunesEpisodeType: "full",
+			Transcripts: []PodcastTranscript{{
+				URL:  baseRequestURL + "/api/podcast/transcript?date=" + episode.Date,
+				Type: "text/plain",
+			}},
+		})
+		if episode.Timings != "" {
+			item := &items[len(items)-1]
+			item.Transcripts = append(item.Transcripts,
+				PodcastTranscript{URL: baseRequestURL + "/api/podcast/transcript.vtt?date=" + episode.Date, Type: "text/vtt"},
+				PodcastTranscript{URL: baseRequestURL + "/api/podcast/transcript.srt?date=" + episode.Date, Type: "application/x-subrip"},
+			)
+			item.Chapters = &PodcastChapterLink{
+				URL:  baseRequestURL + "/api/podcast/chapters.json?date=" + episode.Date,
+				Type: "application/json+chapters",
+			}
+		}
+	}
+
+	channel := Channel{
+		Title:         podcastTitle,
+		Link:          liveURL,
+		Description:   "A bite-sized daily conversation about the latest AI research papers on Hugging Face",
+		LastBuildDate: time.Now().UTC().Format(time.RFC1123Z),
+		AtomLink: AtomLink{
+			Href: requestURL,
+			Rel:  "self",
+			Type: "application/rss+xml",
+		},
+		Language:       "en",
+		ItunesAuthor:   podcastAuthor,
+		ItunesCategory: &ItunesCategory{Text: "Technology"},
+		ItunesExplicit: "false",
+		ItunesType:     "episodic",
+		Items:          items,
+	}
+	if image := os.Getenv("PODCAST_IMAGE_URL"); image != "" {
+		channel.ItunesImage = &ItunesImage{Href: image}
+	}
+
+	rss := RSS{
+		Version:      "2.0",
+		XMLNS:        "http://www.w3.org/2005/Atom",
+		XMLNSItunes:  "http://www.itunes.com/dtds/podcast-1.0.dtd",
+		XMLNSPodcast: "https://podcastindex.org/namespace/1.0",
+		Channel:      channel,
+	}
+
+	output, err := xml.MarshalIndent(rss, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal podcast RSS: %w", err)
+	}
+	return append([]byte(xml.Header), output...), nil
+}
+
+// formatDuration formats a duration as HH:MM:SS for itunes:duration.
+func formatDuration(d time.Duration) string {
+	seconds := int(d.Round(time.Second) / time.Second)
+	return fmt.Sprintf("%02d:%02d:%02d", seconds/3600, seconds%3600/60, seconds%60)
+}
+
+// SegmentTiming places one line of the conversation in the episode audio, in seconds
+type SegmentTiming struct {
+	Speaker string  `json:"speaker"`
+	Text    string  `json:"text"`
+	Paper   string  `json:"paper,omitempty"`
+	Start   float64 `json:"start"`
+	End     float64 `json:"end"`
+}
+
+// PodcastChapters is a Podcasting 2.0 chapters document
+type PodcastChapters struct {
+	Version  string           `json:"version"`
+	Chapters []PodcastChapter `json:"chapters"`
+}
+
+type PodcastChapter struct {
+	StartTime float64 `json:"startTime"`
+	EndTime   float64 `json:"endTime,omitempty"`
+	Title     string  `json:"title"`
+}
+
+// podcastChapters gives each paper a chapter starting at the first line that discusses
+// it. Lines before the first paper form an introduction.
+func podcastChapters(timings []SegmentTiming) []PodcastChapter {
+	var chapters []PodcastChapter
+	seen := make(map[string]bool)
+	for _, t := range timings {
+		if t.Paper == "" || seen[t.Paper] {
+			continue
+		}
+		seen[t.Paper] = true
+		chapters = append(chapters, PodcastChapter{StartTime: t.Start, Title: t.Paper})
+	}
+	if len(timings) == 0 {
+		return chapters
+	}
+	if len(chapters) == 0 || chapters[0].StartTime > 0 {
+		chapters = append([]PodcastChapter{{StartTime: 0, Title: "Introduction"}}, chapters...)
+	}
+
+	end := timings[len(timings)-1].End
+	for i := range chapters {
+		if i+1 < len(chapters) {
+			chapters[i].EndTime = chapters[i+1].StartTime
+		} else {
+			chapters[i].EndTime = end
+		}
+	}
+	return chapters
+}
+
+// formatTimestamp renders seconds as HH:MM:SS followed by sep and milliseconds.
+func formatTimestamp(seconds float64, sep string) string {
+	ms := int64(math.Round(seconds * 1000))
+	return fmt.Sprintf("%02d:%02d:%02d%s%03d", ms/3600000, ms/60000%60, ms/1000%60, sep, ms%1000)
+}
+
+var vttEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// formatWebVTT renders timings as a WebVTT transcript with a voice tag per speaker.
+func formatWebVTT(timings []SegmentTiming) string {
+	var b strings.Builder
+	b.WriteString("WEBVTT\n\n")
+	for i, t := range timings {
+		fmt.Fprintf(&b, "%d\n%s --> %s\n<v %s>%s\n\n", i+1,
+			formatTimestamp(t.Start, "."), formatTimestamp(t.End, "."),
+			vttEscaper.Replace(t.Speaker), vttEscaper.Replace(strings.TrimSpace(t.Text)))
+	}
+	return b.String()
+}
+
+// formatSRT renders timings as an SRT transcript. SRT has no voice tags, so each cue
+// starts with "Speaker: " instead, and its text is kept on one line because a blank
+// line would end the cue early.
+func formatSRT(timings []SegmentTiming) string {
+	var b strings.Builder
+	for i, t := range timings {
+		fmt.Fprintf(&b, "%d\n%s --> %s\n%s: %s\n\n", i+1,
+			formatTimestamp(t.Start, ","), formatTimestamp(t.End, ","),
+			t.Speaker, strings.Join(strings.Fields(t.Text), " "))
+	}
+	return b.String()
+}
+
+// getEpisodeTimings loads the line timings recorded for an episode, or the latest
+// episode if date is empty.
+func getEpisodeTimings(ctx context.Context, date string) ([]SegmentTiming, error) {
+	if blobs == nil {
+		return nil, fmt.Errorf("blob store not configured")
+	}
+	if date == "" {
+		manifest, err := getManifest(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if manifest.Latest == "" {
+			return nil, errNotArchived
+		}
+		date = manifest.Latest
+	}
+
+	data, _, err := blobs.Get(ctx, podcastTimingsKey(date))
+	if errors.Is(err, errBlobNotFound) {
+		return nil, errNotArchived
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get episode timings: %w", err)
+	}
+
+	var timings []SegmentTiming
+	if err := json.Unmarshal(data, &timings); err != nil {
+		return nil, fmt.Errorf("failed to decode episode timings: %w", err)
+	}
+	return timings, nil
+}
+
+// formatTranscript renders a conversation as a plain-text transcript.
+func formatTranscript(conversation string) (string, error) {
+	var data ConversationData
+	if err := json.Unmarshal([]byte(conversation), &data); err != nil {
+		return "", fmt.Errorf("failed to parse conversation: %w", err)
+	}
+
+	return transcriptText(data.Conversation), nil
+}
+
+// transcriptText renders dialogue as plain "Speaker: line" paragraphs.
+func transcriptText(entries []DialogueEntry) string {
+	var transcript strings.Builder
+	for _, entry := range entries {
+		transcript.WriteString(fmt.Sprintf("%s: %s\n\n", entry.Speaker, strings.TrimSpace(entry.Text)))
+	}
+	return transcript.String()
+}
+
+// timingsTranscript renders an episode's recorded lines as a plain transcript.
+func timingsTranscript(timings []SegmentTiming) string {
+	entries := make([]DialogueEntry, len(timings))
+	for i, timing := range timings {
+		entries[i] = DialogueEntry{Speaker: timing.Speaker, Text: timing.Text}
+	}
+	return transcriptText(entries)
+}
+
+func getCachedFeed(ctx context.Context, requestURL string, date string) ([]byte, cacheResult, error) {
+	return cachedArtifact(ctx, dateCacheKey(cacheKey, date), dateCacheDuration(date), func(ctx context.Context) ([]byte, error) {
+		// Rebuild from the archive or generate a new feed
+		feed, err := archivedFeed(ctx, requestURL, date)
+		if err == nil {
+			return feed, nil
+		} else if !errors.Is(err, errNotArchived) {
+			logger.Warn("Failed to read archived papers", "date", date, "error", err)
+		}
+		feed, err = generateFeedDirect(ctx, requestURL, date)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate direct feed: %w", err)
+		}
+		return feed, nil
+	})
+}
+
+func generateFeedDirect(ctx context.Context, requestURL string, date string) ([]byte, error) {
+	// Pass context to scrapePapers
+	papers, err := scrapePapers(ctx, date)
+	if err != nil {
+		return nil, fmt.Errorf("failed scraping papers: %w", err)
+	}
+	// Keep the TL;DRs the scheduled update wrote, e.g. when a stale feed is refreshed
+	if os.Getenv("TLDR_ENABLED") == "true" {
+		restoreTLDRs(ctx, date, papers)
+	}
+	return archiveFeed(ctx, requestURL, date, papers)
+}
+
+// archiveFeed archives a day's papers and renders them as the paper feed.
+func archiveFeed(ctx context.Context, requestURL string, date string, papers []Paper) ([]byte, error) {
+	if data, err := json.Marshal(papers); err == nil {
+		archivePut(ctx, kindPapers, date, data)
+	}
+	return generateRSS(papers, requestURL)
+}
+
+// getCachedTLDRFeed serves the ?view=tldr variant of a day's feed, where each item
+// carries only the TL;DR and why it matters. TL;DRs are written by the scheduled update,
+// so papers without one keep their abstract here.
+func getCachedTLDRFeed(ctx context.Context, requestURL string, date string) ([]byte, cacheResult, error) {
+	return cachedArtifact(ctx, dateCacheKey(tldrFeedCacheKey, date), dateCacheDuration(date), func(ctx context.Context) ([]byte, error) {
+		var papers []Paper
+		if data, err := archiveGet(ctx, kindPapers, date); err == nil && json.Unmarshal(data, &papers) == nil {
+			logger.Info("Using archived papers for TL;DR feed", "date", archiveDate(date))
+		} else {
+			feed, _, err := getCachedFeed(ctx, requestURL, date)
+			if err != nil {
+				return nil, err
+			}
+			papers, err = papersFromRSS(feed)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return generateTLDRFeed(papers, requestURL)
+	})
+}
+
+func generateTLDRFeed(papers []Paper, requestURL string) ([]byte, error) {
+	papers = slices.Clone(papers)
+	for i := range papers {
+		if papers[i].TLDR != "" {
+			papers[i].Abstract = ""
+		}
+	}
+	return marshalPaperRSS(papers, requestURL, defaultFeedLang, "tldr")
+}
+
+// localizeFeed re-renders a cached paper feed with channel metadata in lang. Feeds are
+// cached in the default language only, since the papers themselves are not translated.
+func localizeFeed(feed []byte, requestURL, lang, name string) ([]byte, error) {
+	if lang == defaultFeedLang {
+		return feed, nil
+	}
+	papers, err := papersFromRSS(feed)
+	if err != nil {
+		return nil, err
+	}
+	return marshalPaperRSS(papers, requestURL, lang, name)
+}
+
+// restoreTLDRs fills in the TL;DRs already written for papers, from the archived
+// listing of the date and then from the TL;DR cache, without calling the LLM. Feeds
+// regenerated between scheduled updates keep their TL;DRs this way.
+func restoreTLDRs(ctx context.Context, date string, papers []Paper) {
+	carryOverTLDRs(ctx, date, papers)
+	if !redisConnected {
+		return
+	}
+	for i := range papers {
+		if papers[i].TLDR == "" && papers[i].Abstract != "" {
+			cachedTLDR(ctx, &papers[i])
+		}
+	}
+}
+
+// carryOverTLDRs copies the TL;DRs of a date's archived listing onto papers whose
+// abstract hasn't changed, so the archive keeps them when Redis has evicted its copy.
+func carryOverTLDRs(ctx context.Context, date string, papers []Paper) {
+	if archive == nil {
+		return
+	}
+	data, err := archive.Get(ctx, kindPapers, archiveDate(date))
+	if err != nil {
+		if !errors.Is(err, errNotArchived) {
+			logger.Warn("Failed to read archived TL;DRs", "error", err)
+		}
+		return
+	}
+	var archived []Paper
+	if err := json.Unmarshal(data, &archived); err != nil {
+		logger.Warn("Failed to decode archived TL;DRs", "error", err)
+		return
+	}
+
+	byURL := make(map[string]Paper, len(archived))
+	for _, paper := range archived {
+		byURL[paper.URL] = paper
+	}
+	for i := range papers {
+		if prev, ok := byURL[papers[i].URL]; ok && prev.TLDR != "" && prev.Abstract == papers[i].Abstract {
+			papers[i].TLDR, papers[i].WhyItMatters = prev.TLDR, prev.WhyItMatters
+		}
+	}
+}
+
+// addTLDRs fills in TLDR and WhyItMatters for each paper that lacks them. Results are
+// cached by a hash of the paper's title and scraped abstract, so no paper is summarized
+// twice. Papers whose TL;DR fails keep just their abstract. Generation stops at
+// tldrTimeout, keeping the TL;DRs finished by then.
+func addTLDRs(ctx context.Context, papers []Paper) {
+	client, err := newLLMClient(llmTaskTLDR)
+	if err != nil {
+		logger.Warn("Skipping TL;DR generation", "error", err)
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, tldrTimeout)
+	defer cancel()
+
+	sem := make(chan struct{}, tldrConcurrency)
+	var wg sync.WaitGroup
+	var skipped atomic.Int32
+	for i := range papers {
+		if papers[i].TLDR != "" || papers[i].Abstract == "" {
+			continue
+		}
+		wg.Add(1)
+		go func(paper *Paper) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			if ctx.Err() != nil {
+				skipped.Add(1)
+				return
+			}
+			if err := addTLDR(ctx, client, paper); err != nil {
+				logger.Warn("Failed to generate TL;DR", "url", paper.URL, "error", err)
+			}
+		}(&papers[i])
+	}
+	wg.Wait()
+	if n := skipped.Load(); n > 0 {
+		logger.Warn("TL;DR budget ran out, papers left for the next update", "skipped", n, "timeout", tldrTimeout)
+	}
+}
+
+type paperTLDR struct {
+	TLDR         string `json:"tldr"`
+	WhyItMatters string `json:"why_it_matters"`
+}
+
+// tldrKey caches a paper's TL;DR by its title and scraped abstract.
+func tldrKey(paper *Paper) string {
+	hash := sha256.Sum256([]byte(paper.Title + "\n" + paper.Abstract))
+	return tldrCacheKey + ":" + hex.EncodeToString(hash[:16])
+}
+
+// cachedTLDR fills in a paper's TL;DR from the cache, reporting whether there was one.
+func cachedTLDR(ctx context.Context, paper *Paper) bool {
+	var digest paperTLDR
+	cached, err := rdb.Get(ctx, tldrKey(paper)).Bytes()
+	if err != nil || json.Unmarshal(cached, &digest) != nil || digest.TLDR == "" {
+		return false
+	}
+	paper.TLDR, paper.WhyItMatters = digest.TLDR, digest.WhyItMatters
+	return true
+}
+
+func addTLDR(ctx context.Context, client LLMClient, paper *Paper) error {
+	if redisConnected && cachedTLDR(ctx, paper) {
+		return nil
+	}
+	key := tldrKey(paper)
+	var digest paperTLDR
+
+	prompt := `Write a TL;DR of this AI research paper in one or two plain sentences, and one short sentence on why it matters for practitioners or businesses.
+Return only JSON in this exact format: {"tldr": "", "why_it_matters": ""}
+
+Title: ` + paper.Title + `
+Abstract: ` + paper.Abstract
+
+	completion, err := client.Complete(ctx, []Message{{Role: "user", Content: prompt}})
+	if err != nil {
+		return err
+	}
+	content := completion.Content
+	start, end := strings.Index(content, "{"), strings.LastIndex(content, "}")
+	if start < 0 || end < start {
+		return fmt.Errorf("no JSON found in TL;DR response")
+	}
+	if err := json.Unmarshal([]byte(content[start:end+1]), &digest); err != nil {
+		return fmt.Errorf("failed to parse TL;DR JSON: %w", err)
+	}
+	if digest.TLDR == "" {
+		return fmt.Errorf("TL;DR response was empty")
+	}
+
+	paper.TLDR, paper.WhyItMatters = strings.TrimSpace(digest.TLDR), strings.TrimSpace(digest.WhyItMatters)
+	if redisConnected {
+		data, _ := json.Marshal(digest)
+		if err := rdb.Set(ctx, key, data, historyCacheDuration).Err(); err != nil {
+			logger.Warn("Failed to cache TL;DR", "key", key, "error", err)
+		}
+	}
+	return nil
+}
+
+// archivedFeed rebuilds a feed from the archived papers of a date.
+func archivedFeed(ctx context.Context, requestURL string, date string) ([]byte, error) {
+	data, err := archiveGet(ctx, kindPapers, date)
+	if err != nil {
+		return nil, err
+	}
+	var papers []Paper
+	if err := json.Unmarshal(data, &papers); err != nil {
+		return nil, fmt.Errorf("failed to decode archived papers: %w", err)
+	}
+	logger.Info("Rebuilt feed from archive", "date", archiveDate(date), "papers", len(papers))
+	return generateRSS(papers, requestURL)
+}
+
+// updateAllCaches generates fresh feed and summary data and updates both caches.
+func updateAllCaches(ctx context.Context) error {
+	if !redisConnected {
+		return fmt.Errorf("redis not connected, cannot update caches")
+	}
+	ctx = withScheduledUpdate(ctx)
+
+	logger.Info("Starting cache update for feed and summary")
+
+	// 1. Generate fresh feed data
+	papers, err := scrapePapers(ctx, "")
+	if err != nil {
+		return fmt.Errorf("failed to scrape papers for cache update: %w", err)
+	}
+
+	// Optional stage: embed a TL;DR above each abstract. Only this update writes them,
+	// so no reader ever waits on the LLM for a feed.
+	if os.Getenv("TLDR_ENABLED") == "true" {
+		restoreTLDRs(ctx, "", papers)
+		addTLDRs(ctx, papers)
+	}
+
+	// Use baseURL for the canonical cache content's requestURL in generateRSS
+	freshFeedBytes, err := archiveFeed(ctx, baseURL, "", papers)
+	if err != nil {
+		return fmt.Errorf("failed to generate direct feed for cache update: %w", err)
+	}
+	if tldrFeed, err := generateTLDRFeed(papers, baseURL); err != nil {
+		logger.Error("Failed to generate TL;DR feed", "error", err)
+	} else if err := cacheSet(ctx, tldrFeedCacheKey, tldrFeed, cacheDuration); err != nil {
+		logger.Error("Failed to update TL;DR feed cache", "key", tldrFeedCacheKey, "error", err)
+	}
+
+	// 2. Update feed cache
+	// Use a separate context for Redis operations if needed, but reqCtx is usually fine
+	// Adding a small timeout specifically for Redis Set might be wise.
+	err = cacheSet(ctx, cacheKey, freshFeedBytes, cacheDuration)
+	if err != nil {
+		// Log the error but continue to attempt summary update if possible
+		logger.Error("Failed to update feed cache", "key", cacheKey, "error", err)
+		// Decide if this error should prevent summary update (e.g., return err here)
+		// For now, we log and continue.
+	} else {
+		logger.Info("Successfully updated feed cache", "key", cacheKey)
+	}
+
+	// --- Summary Update ---
+
+	// 3. Parse the *fresh* feed bytes to markdown
+	markdown, err := parseRSSToMarkdown(string(freshFeedBytes))
+	if err != nil {
+		// If parsing fails, we cannot generate a summary. Log and return error.
+		logger.Error("Failed to parse fresh feed to markdown for summary update", "error", err)
+		return fmt.Errorf("failed to parse fresh feed to markdown: %w", err)
+	}
+
+	// 4. Summarize markdown with LLM
+	// summarizeWithLLM bounds the whole step by summaryTimeout. If every configured model
+	// fails, carry on so the conversation and podcast still update from the raw feed.
+	var summaryErr error
+	conversationSource := freshFeedBytes
+	summaryContent, err := summarizeWithLLM(ctx, markdown, defaultSummaryLang)
+	if err != nil {
+		logger.Error("Failed to summarize markdown with LLM for cache update", "error", err)
+		summaryErr = fmt.Errorf("failed to summarize markdown with LLM: %w", err)
+	} else {
+		logger.Info("Summary generated", "model", summaryContent.Model)
+		summaryContent = checkSummaryCitations(summaryContent, freshFeedBytes)
+		if data, err := json.Marshal(summaryContent); err == nil {
+			archivePut(ctx, kindSummaries, "", data)
+		}
+
+		// 5. Generate summary RSS
+		// Use baseURL for the canonical requestURL
+		summaryRSSBytes, err := generateSummaryRSS(summaryContent, baseURL, "", defaultSummaryLang)
+		if err != nil {
+			// If summary RSS generation fails, log and return error.
+			logger.Error("Failed to generate summary RSS for cache update", "error", err)
+			return fmt.Errorf("failed to generate summary RSS: %w", err)
+		}
+
+		logger.Info("Successfully updated both feed and summary caches")
+		// 6. Update summary cache
+		err = cacheSet(ctx, summaryCacheKey, summaryRSSBytes, cacheDuration)
+		if err != nil {
+			// Log the error, but the feed cache might have updated successfully.
+			logger.Error("Failed to update summary cache", "key", summaryCacheKey, "error", err)
+			// Decide if this should return an overall error.
+			// Returning error indicates the full update wasn't successful.
+			return fmt.Errorf("failed to update summary cache: %w", err)
+		} else {
+			logger.Info("Successfully updated summary cache", "key", summaryCacheKey)
+		}
+		conversationSource = summaryRSSBytes
+	}
+
+	// Generate podcast conversation
+	conversation, err := generatePodcastConversation(ctx, string(conversationSource), "")
+	if err != nil {
+		logger.Error("Failed to generate podcast conversation", "error", err)
+		return fmt.Errorf("failed to generate podcast conversation: %w", err)
+	}
+
+	// 7. Update conversation cache
+	err = cacheSet(ctx, conversationCacheKey, []byte(conversation), cacheDuration)
+	if err != nil {
+		logger.Error("Failed to update conversation cache", "key", conversationCacheKey, "error", err)
+		return fmt.Errorf("failed to update conversation cache: %w", err)
+	} else {
+		logger.Info("Successfully updated conversation cache", "key", conversationCacheKey)
+	}
+
+	// In updateAllCaches function
+	// After generating summary RSS
+
+	// Generate and update conversation with proper error handling
+	logger.Info("Starting conversation cache update")
+
+	// Parse RSS bytes to get text content for conversation generation
+	conversation, err = generatePodcastConversation(ctx, string(conversationSource), "")
+	if err != nil {
+		logger.Error("Failed to generate podcast conversation", "error", err)
+		return fmt.Errorf("failed to generate podcast conversation: %w", err)
+	}
+
+	// Add logging before cache update
+	logger.Info("Attempting to update conversation cache",
+		"key", conversationCacheKey,
+		"contentLength", len(conversation))
+
+	// Update conversation cache with proper error handling
+	err = cacheSet(ctx, conversationCacheKey, []byte(conversation), cacheDuration)
+	if err != nil {
+		logger.Error("Failed to update conversation cache",
+			"key", conversationCacheKey,
+			"error", err)
+		return fmt.Errorf("failed to update conversation cache: %w", err)
+	}
+
+	logger.Info("Successfully updated conversation cache",
+		"key", conversationCacheKey)
+
+	// After conversation cache update
+	logger.Info("Starting podcast cache update")
+
+	// Generate audio podcast from conversation
+	audioData, timings, err := generateaudiopodcast(ctx, conversation, "")
+	if err != nil {
+		logger.Error("Failed to generate podcast audio", "error", err)
+		return fmt.Errorf("failed to generate podcast audio: %w", err)
+	}
+
+	// Archive under today's date and point the manifest's latest alias at it
+	today := time.Now().UTC().Format(dateLayout)
+	if blobs != nil {
+		err = archivePodcast(ctx, today, audioData, timings, paperURLsFromFeed(freshFeedBytes))
+		if err != nil {
+			logger.Error("Failed to archive podcast", "date", today, "error", err)
+			return fmt.Errorf("failed to archive podcast: %w", err)
+		}
+	} else {
+		logger.Warn("No blob store configured, podcast will not be stored")
+	}
+
+	logger.Info("Successfully updated podcast cache",
+		"key", podcastKey(today),
+		"size", len(audioData))
+
+	if summaryErr != nil {
+		return summaryErr
+	}
+
+	logger.Info("Successfully updated all caches (feed, summary, conversation, and podcast)")
+	return nil
+}
+
+// markdownPaper is one paper of a feed rendered as markdown for the LLM.
+type markdownPaper struct {
+	Title    string
+	Link     string
+	Abstract string
+	Markdown string
+}
+
+// markdownFeed is a feed rendered as markdown for the LLM: a header followed by one
+// section per paper. The papers are kept apart so the map step never has to split the
+// rendered text again.
+type markdownFeed struct {
+	Header string
+	Papers []markdownPaper
+}
+
+func (f *markdownFeed) String() string {
+	var b strings.Builder
+	b.WriteString(f.Header)
+	writeMarkdownPapers(&b, f.Papers)
+	return b.String()
+}
+
+// writeMarkdownPapers writes each paper's section followed by a rule.
+func writeMarkdownPapers(b *strings.Builder, papers []markdownPaper) {
+	for _, paper := range papers {
+		b.WriteString(paper.Markdown)
+		b.WriteString("---\n\n")
+	}
+}
+
+func parseRSSToMarkdown(xmlContent string) (*markdownFeed, error) {
+	var rss RSS
+	err := xml.Unmarshal([]byte(xmlContent), &rss)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal RSS XML: %w", err)
+	}
+
+	// Format date
+	var formattedDate string
+	parsedDate, err := time.Parse(time.RFC1123Z, rss.Channel.LastBuildDate)
+	if err != nil {
+		formattedDate = rss.Channel.LastBuildDate // Fallback to original format
+	} else {
+		formattedDate = parsedDate.Format("2006-01-02")
+	}
+
+	// Create markdown
+	var header strings.Builder
+	header.WriteString(fmt.Sprintf("# %s\n\n", rss.Channel.Title))
+	header.WriteString(fmt.Sprintf("*%s*\n\n", rss.Channel.Description))
+	header.WriteString(fmt.Sprintf("*Last updated: %s*\n\n", formattedDate))
+	header.WriteString("---\n\n")
+	feed := &markdownFeed{Header: header.String()}
+
+	// Process each item
+	for _, item := range rss.Channel.Items {
+		title := strings.ReplaceAll(item.Title, "\n", " ")
+		title = strings.TrimSpace(title)
+
+		var markdown strings.Builder
+		markdown.WriteString(fmt.Sprintf("## [%s](%s)\n\n", title, item.Link))
+		if item.Creator != "" {
+			markdown.WriteString(fmt.Sprintf("*Authors: %s*\n\n", item.Creator))
+		}
+		if upvotes := item.category(categoryUpvotes); upvotes != "" {
+			markdown.WriteString(fmt.Sprintf("*Upvotes: %s · Comments: %s*\n\n", upvotes, item.category(categoryComments)))
+		}
+		if arxivID := item.category(categoryArxiv); arxivID != "" {
+			markdown.WriteString(fmt.Sprintf("*arXiv: [%s](https://arxiv.org/abs/%s)*\n\n", arxivID, arxivID))
+		}
+		if repo := item.category(categoryGitHub); repo != "" {
+			markdown.WriteString(fmt.Sprintf("*Code: %s*\n\n", repo))
+		}
+		markdown.WriteString(fmt.Sprintf("%s\n\n", item.Description.Text))
+		feed.Papers = append(feed.Papers, markdownPaper{
+			Title:    title,
+			Link:     item.Link,
+			Abstract: item.Description.Text,
+			Markdown: markdown.String(),
+		})
+	}
+
+	return feed, nil
+}
+
+// llmConfigFor resolves the endpoint settings for a task. LLM_<TASK>_<SETTING> wins over
+// LLM_<SETTING>, which wins over the Hugging Face router defaults. Settings are BASE_URL,
+// MODEL, API_KEY, TEMPERATURE, TOP_P, MAX_TOKENS, CONTEXT_TOKENS and JSON_MODE.
+func llmConfigFor(task string) LLMConfig {
+	cfg := llmTaskDefaults[task]
+	prefix := "LLM_" + strings.ToUpper(task) + "_"
+	setting := func(name string) string {
+		if v := os.Getenv(prefix + name); v != "" {
+			return v
+		}
+		return os.Getenv("LLM_" + name)
+	}
+
+	cfg.BaseURL = strings.TrimSuffix(setting("BASE_URL"), "/")
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = defaultLLMBaseURL
+	}
+	cfg.Model = setting("MODEL")
+	if cfg.Model == "" {
+		cfg.Model = defaultLLMModel
+	}
+	cfg.APIKey = setting("API_KEY")
+	if cfg.APIKey == "" && cfg.BaseURL == defaultLLMBaseURL {
+		cfg.APIKey = os.Getenv("HF_API_KEY")
+	}
+	if v, err := strconv.ParseFloat(setting("TEMPERATURE"), 64); err == nil {
+		cfg.Temperature = v
+	}
+	if v, err := strconv.ParseFloat(setting("TOP_P"), 64); err == nil {
+		cfg.TopP = v
+	}
+	if v, err := strconv.Atoi(setting("MAX_TOKENS")); err == nil && v > 0 {
+		cfg.MaxTokens = v
+	}
+	if v, err := strconv.Atoi(setting("CONTEXT_TOKENS")); err == nil && v > 0 {
+		cfg.ContextTokens = v
+	}
+	switch mode := strings.ToLower(setting("JSON_MODE")); mode {
+	case jsonModeSchema, jsonModeObject, jsonModeOff:
+		cfg.JSONMode = mode
+	case "":
+		cfg.JSONMode = jsonModeObject
+	default:
+		logger.Warn("Invalid LLM JSON mode, using default", "task", task, "value", mode, "default", jsonModeObject)
+		cfg.JSONMode = jsonModeObject
+	}
+	return cfg
+}
+
+// llmFallbacksFor lists the ordered fallback configurations for a task, read as a JSON
+// array from LLM_<TASK>_FALLBACKS or LLM_FALLBACKS.
+func llmFallbacksFor(task string, primary LLMConfig) []LLMConfig {
+	raw := os.Getenv("LLM_" + strings.ToUpper(task) + "_FALLBACKS")
+	if raw == "" {
+		raw = os.Getenv("LLM_FALLBACKS")
+	}
+	if raw == "" {
+		return nil
+	}
+
+	var entries []llmFallback
+	if err := json.Unmarshal([]byte(raw), &entries); err != nil {
+		logger.Error("Invalid LLM fallbacks, ignoring them", "task", task, "error", err)
+		return nil
+	}
+
+	configs := make([]LLMConfig, 0, len(entries))
+	for _, entry := range entries {
+		cfg := primary
+		if entry.BaseURL != "" {
+			cfg.BaseURL = strings.TrimSuffix(entry.BaseURL, "/")
+			// A different endpoint never reuses the primary's key
+			if cfg.BaseURL != primary.BaseURL {
+				cfg.APIKey = ""
+			}
+		}
+		if entry.Model != "" {
+			cfg.Model = entry.Model
+		}
+		if entry.APIKeyEnv != "" {
+			cfg.APIKey = os.Getenv(entry.APIKeyEnv)
+		}
+		if entry.APIKey != "" {
+			cfg.APIKey = entry.APIKey
+		}
+		configs = append(configs, cfg)
+	}
+	return configs
+}
+
+// newLLMClient builds the client configured for a task: the primary endpoint followed by
+// any configured fallbacks, each guarded by a circuit breaker.
+func newLLMClient(task string) (LLMClient, error) {
+	primary := llmConfigFor(task)
+
+	var clients []*openAIClient
+	for _, cfg := range append([]LLMConfig{primary}, llmFallbacksFor(task, primary)...) {
+		// Self-hosted servers often need no key, but the default router always does
+		if cfg.APIKey == "" && cfg.BaseURL == defaultLLMBaseURL {
+			logger.Warn("Skipping LLM provider without API key", "task", task, "model", cfg.Model)
+			continue
+		}
+		clients = append(clients, &openAIClient{
+			config: cfg,
+			client: &http.Client{Timeout: llmTimeout},
+		})
+	}
+	if len(clients) == 0 {
+		return nil, fmt.Errorf("HF_API_KEY environment variable is not set")
+	}
+	return &fallbackClient{task: task, clients: clients, breakers: llmBreakers}, nil
+}
+
+// fallbackClient tries each provider in order, skipping those whose circuit is open.
+type fallbackClient struct {
+	task     string
+	clients  []*openAIClient
+	breakers *breakerSet
+}
+
+func (c *fallbackClient) Complete(ctx context.Context, messages []Message) (LLMCompletion, error) {
+	return c.try(ctx, func(ctx context.Context, client *openAIClient) (LLMCompletion, error) {
+		return client.Complete(ctx, messages)
+	})
+}
+
+func (c *fallbackClient) CompleteJSON(ctx context.Context, messages []Message, schema JSONSchema) (LLMCompletion, error) {
+	return c.try(ctx, func(ctx context.Context, client *openAIClient) (LLMCompletion, error) {
+		return client.CompleteJSON(ctx, messages, schema)
+	})
+}
+
+// try runs complete against each provider in turn until one succeeds.
+func (c *fallbackClient) try(ctx context.Context, complete func(context.Context, *openAIClient) (LLMCompletion, error)) (LLMCompletion, error) {
+	var errs []error
+	for i, client := range c.clients {
+		name := client.config.BaseURL + "#" + client.config.Model
+		if !c.breakers.allow(name) {
+			logger.Warn("Skipping LLM provider with open circuit", "task", c.task, "provider", name)
+			errs = append(errs, fmt.Errorf("%s: circuit open", name))
+			continue
+		}
+
+		attemptCtx, cancel := attemptContext(ctx, len(c.clients)-i)
+		completion, err := complete(attemptCtx, client)
+		cancel()
+		if err == nil {
+			c.breakers.success(name)
+			return completion, nil
+		}
+
+		// The caller gave up, so don't blame the provider or try the next one
+		if ctx.Err() != nil {
+			c.breakers.abandon(name)
+			return LLMCompletion{}, err
+		}
+		// Running out of the attempt's own share of time counts as a timeout
+		if isProviderFailure(err) {
+			c.breakers.failure(name)
+		} else {
+			// The provider answered, so release a half-open trial without judging it
+			c.breakers.abandon(name)
+		}
+		logger.Warn("LLM provider failed, trying next", "task", c.task, "provider", name, "error", err)
+		errs = append(errs, fmt.Errorf("%s: %w", name, err))
+	}
+	return LLMCompletion{}, fmt.Errorf("all LLM providers failed for %s: %w", c.task, errors.Join(errs...))
+}
+
+// attemptContext gives one provider its share of the time left on ctx, split evenly
+// across the providers still to try, so a provider that hangs leaves time for the
+// fallbacks. The last provider gets whatever is left.
+func attemptContext(ctx context.Context, remaining int) (context.Context, context.CancelFunc) {
+	deadline, ok := ctx.Deadline()
+	if !ok || remaining <= 1 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, time.Until(deadline)/time.Duration(remaining))
+}
+
+// isProviderFailure reports whether an error points at an unhealthy provider (5xx,
+// rate limiting, timeouts, network errors) rather than at a bad request.
+func isProviderFailure(err error) bool {
+	var statusErr *LLMStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode >= 500 || statusErr.StatusCode == http.StatusTooManyRequests
+	}
+	return true
+}
+
+// breakerSet tracks a circuit breaker per provider. After threshold consecutive
+// failures a circuit opens for cooldown, then lets a single trial request through.
+type breakerSet struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	states    map[string]*breakerState
+}
+
+type breakerState struct {
+	failures  int
+	openUntil time.Time
+	trial     bool
+}
+
+func newBreakerSet(threshold int, cooldown time.Duration) *breakerSet {
+	return &breakerSet{threshold: threshold, cooldown: cooldown, states: make(map[string]*breakerState)}
+}
+
+func (b *breakerSet) allow(name string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	state, ok := b.states[name]
+	if !ok || state.failures < b.threshold {
+		return true
+	}
+	if time.Now().Before(state.openUntil) || state.trial {
+		return false
+	}
+	state.trial = true // half-open: let one request probe the provider
+	return true
+}
+
+func (b *breakerSet) success(name string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.states, name)
+}
+
+// abandon releases a half-open trial whose outcome is unknown.
+func (b *breakerSet) abandon(name string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if state, ok := b.states[name]; ok {
+		state.trial = false
+	}
+}
+
+func (b *breakerSet) failure(name string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	state, ok := b.states[name]
+	if !ok {
+		state = &breakerState{}
+		b.states[name] = state
+	}
+	state.failures++
+	state.trial = false
+	if state.failures >= b.threshold {
+		state.openUntil = time.Now().Add(b.cooldown)
+		logger.Warn("LLM provider circuit opened", "provider", name, "failures", state.failures, "until", state.openUntil)
+	}
+}
+
+// openAIClient talks to any server implementing the OpenAI chat completions API,
+// including the Hugging Face router and local servers such as Ollama or vLLM.
+type openAIClient struct {
+	config LLMConfig
+	client *http.Client
+}
+
+func (c *openAIClient) Complete(ctx context.Context, messages []Message) (LLMCompletion, error) {
+	return c.complete(ctx, messages, nil)
+}
+
+func (c *openAIClient) CompleteJSON(ctx context.Context, messages []Message, schema JSONSchema) (LLMCompletion, error) {
+	var format *ResponseFormat
+	switch c.config.JSONMode {
+	case jsonModeSchema:
+		format = &ResponseFormat{Type: jsonModeSchema, JSONSchema: &schema}
+	case jsonModeObject:
+		format = &ResponseFormat{Type: jsonModeObject}
+	}
+
+	completion, err := c.complete(ctx, messages, format)
+	// Servers without JSON mode reject the field, so ask again in plain text
+	var statusErr *LLMStatusError
+	if format != nil && errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusBadRequest {
+		logger.Warn("LLM provider rejected JSON mode, retrying without it", "model", c.config.Model, "mode", c.config.JSONMode, "error", err)
+		return c.complete(ctx, messages, nil)
+	}
+	return completion, err
+}
+
+func (c *openAIClient) complete(ctx context.Context, messages []Message, format *ResponseFormat) (LLMCompletion, error) {
+	apiURL := c.config.BaseURL + "/chat/completions"
+
+	request := LLMRequest{
+		Model:          c.config.Model,
+		Messages:       messages,
+		MaxTokens:      c.config.MaxTokens,
+		Stream:         false,
+		Temperature:    c.config.Temperature,
+		TopP:           c.config.TopP,
+		ResponseFormat: format,
+	}
+
+	requestBody, err := json.Marshal(request)
+	if err != nil {
+		return LLMCompletion{}, fmt.Errorf("failed to marshal LLM request: %w", err)
+	}
+
+	// Create request with context
+	req, err := http.NewRequestWithContext(ctx, "POST", apiURL, bytes.NewBuffer(requestBody))
+	if err != nil {
+		return LLMCompletion{}, fmt.Errorf("failed to create LLM request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if c.config.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.config.APIKey)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return LLMCompletion{}, fmt.Errorf("timeout calling LLM API at %s: %w", apiURL, err)
+		}
+		return LLMCompletion{}, fmt.Errorf("failed to send request to LLM API at %s: %w", apiURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return LLMCompletion{}, &LLMStatusError{URL: apiURL, StatusCode: resp.StatusCode, Body: string(bodyBytes)}
+	}
+
+	var llmResp LLMResponse
+	if err := json.NewDecoder(resp.Body).Decode(&llmResp); err != nil {
+		return LLMCompletion{}, fmt.Errorf("failed to decode LLM response: %w", err)
+	}
+
+	if len(llmResp.Choices) == 0 || llmResp.Choices[0].Message.Content == "" {
+		logger.Warn("LLM response contained no choices or empty content", "model", c.config.Model, "response", llmResp)
+		return LLMCompletion{}, fmt.Errorf("no valid response content returned from LLM API at %s", apiURL)
+	}
+
+	response := llmResp.Choices[0].Message.Content
+
+	// Extract only the content after <think> tags if present
+	if strings.Contains(response, "<think>") {
+		parts := strings.Split(response, "</think>")
 		if len(parts) > 1 {
 			response = strings.TrimSpace(parts[len(parts)-1])
 		}
 	}
 
-	return response, nil
+	// Prefer the model name the server reports, since routers may resolve aliases
+	model := llmResp.Model
+	if model == "" {
+		model = c.config.Model
+	}
+	return LLMCompletion{Content: response, Model: model}, nil
+}
+
+// condensePrompt asks for short notes on a chunk of papers during the map step
+const condensePrompt = `Condense each of the AI research papers below into one or two sentences covering what is new and the key results or metrics. Keep every paper. Return JSON of the form {"papers": [{"link": "...", "notes": "..."}]} with one entry per paper, using each paper's link exactly as given in its heading.
+
+`
+
+// condenseSchema describes the map step's notes for providers with structured output.
+func condenseSchema() JSONSchema {
+	schema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"papers": map[string]any{
+				"type": "array",
+				"items": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"link":  map[string]any{"type": "string"},
+						"notes": map[string]any{"type": "string", "minLength": 1},
+					},
+					"required":             []string{"link", "notes"},
+					"additionalProperties": false,
+				},
+			},
+		},
+		"required":             []string{"papers"},
+		"additionalProperties": false,
+	}
+	data, _ := json.Marshal(schema)
+	return JSONSchema{Name: "paper_notes", Schema: data}
+}
+
+// condensedNotes is the map step's answer for one chunk of papers.
+type condensedNotes struct {
+	Papers []struct {
+		Link  string `json:"link"`
+		Notes string `json:"notes"`
+	} `json:"papers"`
+}
+
+// summarizeWithLLM summarizes the papers using the LLM configured for summaries. The
+// whole step gets summaryTimeout, of which a busy day's map step may use half, so
+// some time is always left for writing the briefing.
+func summarizeWithLLM(ctx context.Context, feed *markdownFeed, lang string) (LLMCompletion, error) {
+	client, err := newLLMClient(llmTaskSummary)
+	if err != nil {
+		return LLMCompletion{}, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, summaryTimeout)
+	defer cancel()
+
+	// Busy days can overflow the context window, so condense the papers first (map)
+	// and write the briefing from the condensed notes (reduce)
+	cfg := llmConfigFor(llmTaskSummary)
+	budget := max(cfg.ContextTokens-cfg.MaxTokens-summaryPromptTokens, minSummaryBudget)
+	markdownContent := feed.String()
+	if tokens := estimateTokens(markdownContent); tokens > budget {
+		logger.Info("Papers exceed summary context budget, condensing in chunks", "tokens", tokens, "budget", budget)
+		mapCtx, cancelMap := context.WithTimeout(ctx, summaryTimeout/2)
+		papers, err := condensePapers(mapCtx, client, feed.Papers, budget)
+		cancelMap()
+		if err != nil {
+			return LLMCompletion{}, fmt.Errorf("failed to condense papers for summary: %w", err)
+		}
+		markdownContent = (&markdownFeed{Header: feed.Header, Papers: papers}).String()
+	}
+
+	prompt := `Create a brief morning briefing on these AI research papers, written in a conversational style for busy professionals. Focus on what's new and what it means for businesses and society.
+Format the output in HTML:
+<h2>` + translate(lang, "summary.headline") + `</h2>
+<p>(1 sentence)</p>
+
+<h2>` + translate(lang, "summary.whatsnew") + `</h2>
+<p>(2-3 sentences, written like you're explaining it to a friend over coffee, with citations to papers as <a href="link">Paper Name</a>, using only the paper links given below)</p>
+<ul>
+  <li>Cover all papers in a natural, flowing narrative</li>
+  <li>Group related papers together</li>
+  <li>Include key metrics and outcomes</li>
+  <li>Keep the tone light and engaging</li>
+</ul>
+
+Keep it under 200 words. Start with the most impressive or important paper. Focus on outcomes and implications, not technical details. Write like you're explaining it to a friend over coffee. Do not write a word count.
+
+Do not enclose the HTML in a markdown code block, just return the HTML.
+
+Write the entire briefing in ` + translate(lang, "language") + `, keeping paper titles as they are.
+
+Below are the paper abstracts and information in markdown format:
+` + markdownContent
+
+	// Publish only sanitized HTML, and ask the model to try again when the briefing
+	// lacks its required sections or runs over the word limit. A briefing that is only
+	// too long is still published once the attempts or the time run out, and coverage
+	// of individual papers is reported by checkSummaryCitations rather than enforced, as
+	// a briefing this short can't cite every paper of a busy day.
+	messages := []Message{
+		{
+			Role:    "user",
+			Content: prompt,
+		},
+	}
+	var tooLong *LLMCompletion
+	for attempt := 1; ; attempt++ {
+		completion, err := client.Complete(ctx, messages)
+		if err != nil {
+			if tooLong != nil {
+				logger.Warn("Summary retry failed, publishing the over-long briefing", "error", err, "model", tooLong.Model)
+				return *tooLong, nil
+			}
+			return LLMCompletion{}, err
+		}
+		completion.Content = sanitizeSummaryHTML(completion.Content)
+
+		var problems []string
+		missing := missingSummarySections(completion.Content, lang)
+		if len(missing) > 0 {
+			problems = append(problems, fmt.Sprintf("The briefing is missing the required <h2>%s</h2> section(s).", strings.Join(missing, "</h2> and <h2>")))
+		}
+		words := summaryWords(html2text(completion.Content))
+		if words > maxSummaryWords {
+			problems = append(problems, fmt.Sprintf("The briefing is about %d words long; keep it under %d words.", words, maxSummaryWords))
+			if len(missing) == 0 {
+				tooLong = &completion
+			}
+		}
+		if len(problems) == 0 {
+			return completion, nil
+		}
+		if attempt == maxSummaryAttempts {
+			if tooLong != nil {
+				logger.Warn("Summary exceeds word limit, publishing it anyway", "words", summaryWords(html2text(tooLong.Content)), "limit", maxSummaryWords, "model", tooLong.Model)
+				return *tooLong, nil
+			}
+			return LLMCompletion{}, fmt.Errorf("summary from %s is missing required sections after %d attempts: %s", completion.Model, attempt, strings.Join(problems, " "))
+		}
+		logger.Warn("Summary is invalid, regenerating", "missing", missing, "words", words, "model", completion.Model, "attempt", attempt)
+		messages = append(messages,
+			Message{Role: "assistant", Content: completion.Content},
+			Message{Role: "user", Content: strings.Join(problems, " ") + " Return the complete briefing again in the HTML format described above."},
+		)
+	}
+}
+
+// summaryWords measures a briefing against maxSummaryWords. Scripts written without
+// spaces count two characters as a word, roughly what a word of English says.
+func summaryWords(text string) int {
+	words, wide := 0, 0
+	for _, field := range strings.Fields(text) {
+		latin := false
+		for _, r := range field {
+			if unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Thai) {
+				wide++
+			} else if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				latin = true
+			}
+		}
+		if latin {
+			words++
+		}
+	}
+	return words + (wide+1)/2
+}
+
+// estimateTokens approximates the token count of text at roughly four characters per token.
+func estimateTokens(text string) int {
+	return (utf8.RuneCountInString(text) + 3) / 4
+}
+
+// chunkPapers groups papers into chunks of at most budget tokens. A paper larger than
+// the budget gets a chunk of its own.
+func chunkPapers(papers []markdownPaper, budget int) [][]markdownPaper {
+	var chunks [][]markdownPaper
+	var current []markdownPaper
+	tokens := 0
+	for _, paper := range papers {
+		paperTokens := estimateTokens(paper.Markdown + "---\n\n")
+		if len(current) > 0 && tokens+paperTokens > budget {
+			chunks = append(chunks, current)
+			current, tokens = nil, 0
+		}
+		current = append(current, paper)
+		tokens += paperTokens
+	}
+	if len(current) > 0 {
+		chunks = append(chunks, current)
+	}
+	return chunks
+}
+
+// condensePapers is the map step of map-reduce summarization: it summarizes the papers in
+// chunks that each fit the budget, repeating on the notes until they fit as a whole.
+func condensePapers(ctx context.Context, client LLMClient, papers []markdownPaper, budget int) ([]markdownPaper, error) {
+	for round := 1; ; round++ {
+		chunks := chunkPapers(papers, budget)
+		logger.Info("Condensing papers", "round", round, "papers", len(papers), "chunks", len(chunks))
+
+		notes := make([][]markdownPaper, len(chunks))
+		errs := make([]error, len(chunks))
+		sem := make(chan struct{}, summaryMapConcurrency)
+		var wg sync.WaitGroup
+		for i, chunk := range chunks {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+				notes[i], errs[i] = condenseChunk(ctx, client, chunk)
+			}()
+		}
+		wg.Wait()
+		if err := errors.Join(errs...); err != nil {
+			return nil, err
+		}
+
+		papers = slices.Concat(notes...)
+		var condensed strings.Builder
+		writeMarkdownPapers(&condensed, papers)
+		// Stop once the notes fit, or when another round can't shrink them any further
+		if estimateTokens(condensed.String()) <= budget || len(chunks) == 1 || round == maxCondenseRounds {
+			return papers, nil
+		}
+	}
+}
+
+// condenseChunk asks for notes on every paper of a chunk, matched back to the papers by
+// link. Papers the model leaves out are asked for again, and any still missing after
+// maxCondenseAttempts fall back to the start of their abstract, so every paper reaches
+// the briefing.
+func condenseChunk(ctx context.Context, client LLMClient, chunk []markdownPaper) ([]markdownPaper, error) {
+	var input strings.Builder
+	writeMarkdownPapers(&input, chunk)
+	messages := []Message{{Role: "user", Content: condensePrompt + input.String()}}
+
+	notes := make(map[string]string, len(chunk))
+	var missing []markdownPaper
+	for attempt := 1; attempt <= maxCondenseAttempts; attempt++ {
+		completion, err := client.CompleteJSON(ctx, messages, condenseSchema())
+		if err != nil {
+			return nil, err
+		}
+
+		var answer condensedNotes
+		if err := decodeJSONResponse(completion.Content, &answer, func() bool { return answer.Papers != nil }); err != nil {
+			logger.Warn("Failed to decode paper notes", "error", err, "model", completion.Model, "attempt", attempt)
+		}
+		for _, paper := range answer.Papers {
+			if note := strings.TrimSpace(paper.Notes); note != "" {
+				notes[normalizeLink(paper.Link)] = note
+			}
+		}
+
+		missing = missing[:0]
+		for _, paper := range chunk {
+			if notes[normalizeLink(paper.Link)] == "" {
+				missing = append(missing, paper)
+			}
+		}
+		if len(missing) == 0 {
+			break
+		}
+
+		var retry strings.Builder
+		retry.WriteString("Notes are missing for these papers. Return the notes for every paper again in the same JSON format, using each link exactly as given:\n")
+		for _, paper := range missing {
+			retry.WriteString(fmt.Sprintf("- [%s](%s)\n", paper.Title, paper.Link))
+		}
+		messages = append(messages,
+			Message{Role: "assistant", Content: completion.Content},
+			Message{Role: "user", Content: retry.String()},
+		)
+	}
+	if len(missing) > 0 {
+		logger.Warn("Model left papers out of its notes, using their abstracts", "missing", len(missing), "papers", len(chunk))
+	}
+
+	condensed := make([]markdownPaper, len(chunk))
+	for i, paper := range chunk {
+		note := notes[normalizeLink(paper.Link)]
+		if note == "" {
+			note = truncateWords(html2text(paper.Abstract), condenseFallbackWords)
+		}
+		paper.Markdown = fmt.Sprintf("- [%s](%s): %s\n\n", paper.Title, paper.Link, note)
+		condensed[i] = paper
+	}
+	return condensed, nil
+}
+
+// truncateWords shortens text to at most n words.
+func truncateWords(text string, n int) string {
+	words := strings.Fields(text)
+	if len(words) <= n {
+		return strings.Join(words, " ")
+	}
+	return strings.Join(words[:n], " ") + "…"
+}
+
+// html2text strips tags from generated HTML for word counting.
+func html2text(s string) string {
+	doc, err := html.Parse(strings.NewReader(s))
+	if err != nil {
+		return s
+	}
+	return extractText(doc)
+}
+
+// summaryAllowedTags lists the elements a published summary may contain. Anything
+// else is unwrapped to its text, except summaryDroppedTags which are removed entirely.
+var summaryAllowedTags = map[string]bool{"h2": true, "p": true, "ul": true, "li": true, "a": true}
+
+var summaryDroppedTags = map[string]bool{
+	"script": true, "style": true, "iframe": true, "object": true, "embed": true,
+	"noscript": true, "template": true, "svg": true, "math": true, "head": true, "title": true,
+}
+
+var codeFenceRe = regexp.MustCompile("(?m)^\\s*```[a-zA-Z]*\\s*$")
+
+// sanitizeSummaryHTML reduces model output to the allowlisted tags, drops all attributes
+// except http(s) link targets and re-renders it so the markup is always balanced. The
+// result is safe to embed in a CDATA section.
+func sanitizeSummaryHTML(s string) string {
+	s = codeFenceRe.ReplaceAllString(s, "")
+	nodes, err := html.ParseFragment(strings.NewReader(s), &html.Node{
+		Type:     html.ElementNode,
+		Data:     "body",
+		DataAtom: atom.Body,
+	})
+	if err != nil {
+		return html.EscapeString(s)
+	}
+
+	var b strings.Builder
+	for _, n := range nodes {
+		renderSummaryNode(&b, n)
+	}
+	// Text and attributes are escaped above, but never let a CDATA terminator through
+	return strings.ReplaceAll(strings.TrimSpace(b.String()), "]]>", "]]&gt;")
+}
+
+func renderSummaryNode(b *strings.Builder, n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		b.WriteString(html.EscapeString(n.Data))
+		return
+	case html.ElementNode:
+		if summaryDroppedTags[n.Data] {
+			return
+		}
+	default:
+		// Comments and doctypes
+		return
+	}
+
+	tag := ""
+	if summaryAllowedTags[n.Data] {
+		tag = n.Data
+		if tag == "a" {
+			href := safeHref(getAttr(n, "href"))
+			if href == "" {
+				tag = ""
+			} else {
+				b.WriteString(`<a href="` + html.EscapeString(href) + `">`)
+			}
+		} else {
+			b.WriteString("<" + tag + ">")
+		}
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		renderSummaryNode(b, c)
+	}
+	if tag != "" {
+		b.WriteString("</" + tag + ">")
+	}
+}
+
+// safeHref returns href if it is an absolute http(s) URL, or "" otherwise.
+func safeHref(href string) string {
+	u, err := url.Parse(strings.TrimSpace(href))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ""
+	}
+	return u.String()
+}
+
+// missingSummarySections returns the required h2 headings that the summary lacks.
+func missingSummarySections(summary, lang string) []string {
+	nodes, err := html.ParseFragment(strings.NewReader(summary), &html.Node{
+		Type:     html.ElementNode,
+		Data:     "body",
+		DataAtom: atom.Body,
+	})
+	if err != nil {
+		return []string{translate(lang, "summary.headline"), translate(lang, "summary.whatsnew")}
+	}
+
+	headings := make(map[string]bool)
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.Data == "h2" {
+			headings[normalizeHeading(extractText(n))] = true
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	for _, n := range nodes {
+		walk(n)
+	}
+
+	var missing []string
+	for _, key := range []string{"summary.headline", "summary.whatsnew"} {
+		heading := translate(lang, key)
+		// Models sometimes answer with the English headings despite the language
+		// instruction, which is still a well-formed briefing
+		if !headings[normalizeHeading(heading)] && !headings[normalizeHeading(translate("en", key))] {
+			missing = append(missing, heading)
+		}
+	}
+	return missing
+}
+
+// normalizeHeading folds case, whitespace and typographic apostrophes for comparing headings.
+func normalizeHeading(s string) string {
+	s = strings.ReplaceAll(s, "’", "'")
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
+
+// CitationReport records how well a summary's links cover the papers it summarizes
+type CitationReport struct {
+	Papers    int      `json:"papers"`
+	Cited     int      `json:"cited"`
+	Rewritten int      `json:"rewritten"`
+	Removed   int      `json:"removed"`
+	Uncited   []string `json:"uncited,omitempty"`
+}
+
+// Coverage is the share of papers the summary links to.
+func (r CitationReport) Coverage() float64 {
+	if r.Papers == 0 {
+		return 0
+	}
+	return float64(r.Cited) / float64(r.Papers)
+}
+
+var arxivIDInURL = regexp.MustCompile(`\d{4}\.\d{4,5}`)
+
+// verifyCitations checks every link in a sanitized summary against the papers it was
+// written from. Links that name a known paper by arXiv ID or anchor text are pointed at
+// that paper, any other link is removed and its text kept.
+func verifyCitations(summary string, papers []Paper) (string, CitationReport) {
+	report := CitationReport{Papers: len(papers)}
+	byURL := make(map[string]int, len(papers))
+	byArxiv := make(map[string]int, len(papers))
+	byTitle := make(map[string]int, len(papers))
+	for i, paper := range papers {
+		byURL[normalizeLink(paper.URL)] = i
+		if paper.ArxivID != "" {
+			byArxiv[paper.ArxivID] = i
+		}
+		byTitle[normalizeHeading(paper.Title)] = i
+	}
+
+	nodes, err := html.ParseFragment(strings.NewReader(summary), &html.Node{
+		Type:     html.ElementNode,
+		Data:     "body",
+		DataAtom: atom.Body,
+	})
+	if err != nil {
+		return summary, report
+	}
+
+	cited := make(map[int]bool)
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		for c := n.FirstChild; c != nil; {
+			next := c.NextSibling
+			walk(c)
+			c = next
+		}
+		if n.Type != html.ElementNode || n.Data != "a" {
+			return
+		}
+
+		href := getAttr(n, "href")
+		i, ok := byURL[normalizeLink(href)]
+		if !ok {
+			if id := arxivIDInURL.FindString(href); id != "" {
+				i, ok = byArxiv[id]
+			}
+			if !ok {
+				i, ok = byTitle[normalizeHeading(extractText(n))]
+			}
+			if ok {
+				report.Rewritten++
+				setAttr(n, "href", papers[i].URL)
+			}
+		}
+		if ok {
+			cited[i] = true
+			return
+		}
+
+		// Unknown link, keep the anchor text in its place
+		report.Removed++
+		for c := n.FirstChild; c != nil; {
+			next := c.NextSibling
+			n.RemoveChild(c)
+			n.Parent.InsertBefore(c, n)
+			c = next
+		}
+		n.Parent.RemoveChild(n)
+	}
+
+	// Wrap the fragment so top-level anchors have a parent to be unwrapped into
+	root := &html.Node{Type: html.ElementNode, Data: "div", DataAtom: atom.Div}
+	for _, n := range nodes {
+		root.AppendChild(n)
+	}
+	walk(root)
+
+	var b strings.Builder
+	for c := root.FirstChild; c != nil; c = c.NextSibling {
+		renderSummaryNode(&b, c)
+	}
+
+	report.Cited = len(cited)
+	for i, paper := range papers {
+		if !cited[i] {
+			report.Uncited = append(report.Uncited, paper.Title)
+		}
+	}
+	return strings.ReplaceAll(strings.TrimSpace(b.String()), "]]>", "]]&gt;"), report
+}
+
+// normalizeLink reduces a URL to host and path so scheme and trailing slash
+// differences don't count as a different link.
+func normalizeLink(link string) string {
+	u, err := url.Parse(strings.TrimSpace(link))
+	if err != nil {
+		return link
+	}
+	return strings.ToLower(strings.TrimPrefix(u.Host, "www.")) + strings.TrimSuffix(u.Path, "/")
+}
+
+func setAttr(n *html.Node, key, val string) {
+	for i, attr := range n.Attr {
+		if attr.Key == key {
+			n.Attr[i].Val = val
+			return
+		}
+	}
+	n.Attr = append(n.Attr, html.Attribute{Key: key, Val: val})
+}
+
+// checkSummaryCitations verifies the links in a summary against the feed it summarizes
+// and records the coverage on the summary.
+func checkSummaryCitations(summary LLMCompletion, feed []byte) LLMCompletion {
+	papers, err := papersFromRSS(feed)
+	if err != nil {
+		logger.Warn("Skipping citation check, failed to parse source feed", "error", err)
+		return summary
+	}
+
+	content, report := verifyCitations(summary.Content, papers)
+	summary.Content = content
+	summary.Citations = &report
+	logger.Info("Checked summary citations",
+		"papers", report.Papers,
+		"cited", report.Cited,
+		"coverage", fmt.Sprintf("%.0f%%", report.Coverage()*100),
+		"rewritten", report.Rewritten,
+		"removed", report.Removed,
+		"model", summary.Model)
+	if len(report.Uncited) > 0 {
+		logger.Info("Papers not cited in summary", "titles", report.Uncited)
+	}
+	return summary
+}
+
+func generateSummaryRSS(summary LLMCompletion, requestURL string, date string, lang string) ([]byte, error) {
+	now := listingTime(date)
+	return marshalSummaryRSS(summary, requestURL, lang,
+		fmt.Sprintf(translate(lang, "summary.item"), localizedDate(lang, now)),
+		langCacheKey(fmt.Sprintf("summary-%s", now.Format("2006-01-02")), lang, defaultSummaryLang),
+		now)
+}
+
+// marshalSummaryRSS renders a single LLM summary as an RSS channel with one item.
+// The model that wrote the summary is recorded as the channel generator.
+func marshalSummaryRSS(summary LLMCompletion, requestURL, lang, title, guid string, now time.Time) ([]byte, error) {
+	// Ensure the summary is properly wrapped in a div for better HTML structure. Archived
+	// summaries may predate sanitizing, so sanitize again before publishing.
+	content := fmt.Sprintf("<div>%s</div>", sanitizeSummaryHTML(summary.Content))
+
+	item := Item{
+		Title:       title,
+		Link:        liveURL,
+		Description: CDATA{Text: content},
+		PubDate:     now.Format(time.RFC1123Z),
+		GUID: GUID{
+			IsPermaLink: false,
+			Text:        guid,
+		},
+	}
+	if c := summary.Citations; c != nil {
+		item.Categories = append(item.Categories,
+			Category{Domain: categoryCitations, Text: fmt.Sprintf("%d/%d", c.Cited, c.Papers)})
+	}
+
+	rss := RSS{
+		Version: "2.0",
+		XMLNS:   "http://www.w3.org/2005/Atom",
+		Channel: Channel{
+			Title:         translate(lang, "summary.title"),
+			Link:          liveURL,
+			Description:   translate(lang, "summary.description"),
+			LastBuildDate: now.Format(time.RFC1123Z),
+			Generator:     summary.Model,
+			Language:      lang,
+			AtomLink: AtomLink{
+				Href: requestURL,
+				Rel:  "self",
+				Type: "application/rss+xml",
+			},
+			Items: []Item{item},
+		},
+	}
+
+	// Add XML header and proper encoding
+	output, err := xml.MarshalIndent(rss, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal summary RSS: %w", err)
+	}
+
+	// Prepend the XML header
+	return append([]byte(xml.Header), output...), nil
+}
+
+// getCachedSummary retrieves the summary from cache or generates it if missed.
+// It now accepts a context for Redis operations and summary generation.
+func getCachedSummary(ctx context.Context, requestURL string, date string, lang string) ([]byte, cacheResult, error) {
+	key := dateCacheKey(langCacheKey(summaryCacheKey, lang, defaultSummaryLang), date)
+	return cachedArtifact(ctx, key, dateCacheDuration(date), func(ctx context.Context) ([]byte, error) {
+		// Rebuild from the archive or generate a new summary
+		var summary []byte
+		var archived LLMCompletion
+		data, err := archiveGet(ctx, summaryArchiveKind(lang), date)
+		if err == nil {
+			err = json.Unmarshal(data, &archived)
+		}
+		if err == nil {
+			logger.Info("Rebuilt summary from archive", "date", archiveDate(date), "lang", lang)
+			summary, err = generateSummaryRSS(archived, requestURL, date, lang)
+		} else {
+			if !errors.Is(err, errNotArchived) {
+				logger.Warn("Failed to read archived summary", "date", date, "error", err)
+			}
+			logger.Info("Summary cache miss, generating new summary", "lang", lang)
+			summary, err = generateSummaryDirect(ctx, requestURL, date, lang)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate summary directly after cache miss: %w", err)
+		}
+		return summary, nil
+	})
+}
+
+// generateSummaryDirect generates the summary by getting feed, parsing, and calling LLM.
+// It now accepts a context to pass down the call chain.
+func generateSummaryDirect(ctx context.Context, requestURL string, date string, lang string) ([]byte, error) {
+	// Get the feed content, passing context
+	// This now correctly uses the feed cache if available, or generates directly.
+	feedBytes, _, err := getCachedFeed(ctx, requestURL, date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feed for summary generation: %w", err)
+	}
+
+	// Convert feed to markdown (no context needed for this part)
+	markdown, err := parseRSSToMarkdown(string(feedBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse RSS to markdown for summary generation: %w", err)
+	}
+
+	// Summarize with LLM, passing context
+	summaryContent, err := summarizeWithLLM(ctx, markdown, lang)
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize markdown with LLM: %w", err)
+	}
+	summaryContent = checkSummaryCitations(summaryContent, feedBytes)
+	if data, err := json.Marshal(summaryContent); err == nil {
+		archivePut(ctx, summaryArchiveKind(lang), date, data)
+	}
+
+	// Use the original requestURL for the summary RSS self-link
+	return generateSummaryRSS(summaryContent, requestURL, date, lang)
+}
+
+// rollupPeriod describes a feed that combines several daily listings.
+type rollupPeriod struct {
+	Name            string
+	Days            int
+	CacheKey        string
+	SummaryCacheKey string
+}
+
+var rollupPeriods = map[string]rollupPeriod{
+	"weekly": {
+		Name:            "weekly",
+		Days:            7,
+		CacheKey:        "hf_papers_weekly_cache",
+		SummaryCacheKey: "hf_papers_weekly_summary_cache",
+	},
+	"monthly": {
+		Name:            "monthly",
+		Days:            30,
+		CacheKey:        "hf_papers_monthly_cache",
+		SummaryCacheKey: "hf_papers_monthly_summary_cache",
+	},
+}
+
+// rollupDates lists the listing dates covered by a period ending at date (today if empty).
+// The first entry is always the end date itself.
+func rollupDates(period rollupPeriod, date string) []string {
+	end := listingTime(date)
+	dates := make([]string, period.Days)
+	for i := range dates {
+		dates[i] = end.AddDate(0, 0, -i).Format(dateLayout)
+	}
+	if date == "" {
+		dates[0] = "" // today's listing lives under the undated cache key
+	}
+	return dates
+}
+
+// collectRollupPapers gathers the daily papers for a period, removes duplicates by URL
+// and ranks the rest by upvotes. Days that fail to load are skipped. Ranking only needs
+// listing metadata, so abstracts are fetched afterwards for the top papers alone.
+func collectRollupPapers(ctx context.Context, period rollupPeriod, date string) []Paper {
+	dates := rollupDates(period, date)
+	daily := make([][]Paper, len(dates))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < rollupConcurrency && w < len(dates); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				papers, err := rollupDayPapers(ctx, dates[i])
+				if err != nil {
+					logger.Warn("Skipping day in roll-up", "period", period.Name, "date", dates[i], "error", err)
+					continue
+				}
+				daily[i] = papers
+			}
+		}()
+	}
+	for i := range dates {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	// Keep the copy with the most upvotes when a paper trends on several days
+	seen := make(map[string]int)
+	var papers []Paper
+	for _, day := range daily {
+		for _, paper := range day {
+			if i, ok := seen[paper.URL]; ok {
+				if paper.Upvotes > papers[i].Upvotes {
+					papers[i] = paper
+				}
+				continue
+			}
+			seen[paper.URL] = len(papers)
+			papers = append(papers, paper)
+		}
+	}
+
+	sort.SliceStable(papers, func(i, j int) bool {
+		return papers[i].Upvotes > papers[j].Upvotes
+	})
+	if len(papers) > rollupTopPapers {
+		papers = papers[:rollupTopPapers]
+	}
+	fetchAbstracts(ctx, papers, envInt("SCRAPE_CONCURRENCY", defaultScrapeConcurrency))
+	return papers
+}
+
+// rollupDayPapers returns a day's papers from the feed cache or the archive when they
+// are there, otherwise from the listing page alone, leaving out abstracts.
+func rollupDayPapers(ctx context.Context, date string) ([]Paper, error) {
+	if redisConnected {
+		if entry, err := cacheGet(ctx, dateCacheKey(cacheKey, date)); err == nil {
+			return papersFromRSS(entry.Data)
+		}
+	}
+	if data, err := archiveGet(ctx, kindPapers, date); err == nil {
+		var papers []Paper
+		if err := json.Unmarshal(data, &papers); err == nil {
+			return papers, nil
+		}
+	}
+	return scrapeListing(ctx, date)
+}
+
+// papersFromRSS rebuilds papers from a cached paper feed.
+func papersFromRSS(feed []byte) ([]Paper, error) {
+	var rss RSS
+	if err := xml.Unmarshal(feed, &rss); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal RSS XML: %w", err)
+	}
+
+	papers := make([]Paper, 0, len(rss.Channel.Items))
+	for _, item := range rss.Channel.Items {
+		paper := Paper{
+			Title:     strings.TrimSpace(item.Title),
+			URL:       item.Link,
+			ArxivID:   item.category(categoryArxiv),
+			GitHubURL: item.category(categoryGitHub),
+		}
+		paper.TLDR, paper.WhyItMatters, paper.Abstract = splitPaperDescription(item.Description.Text)
+		if item.Creator != "" {
+			paper.Authors = strings.Split(item.Creator, ", ")
+		}
+		if item.Thumbnail != nil {
+			paper.Thumbnail = item.Thumbnail.URL
+		}
+		paper.Upvotes, _ = strconv.Atoi(item.category(categoryUpvotes))
+		paper.Comments, _ = strconv.Atoi(item.category(categoryComments))
+		if pubDate, err := time.Parse(time.RFC1123Z, item.PubDate); err == nil {
+			paper.PubDate = pubDate
+		}
+		papers = append(papers, paper)
+	}
+	return papers, nil
+}
+
+// getCachedRollupFeed returns the ranked roll-up feed for a period ending at date.
+func getCachedRollupFeed(ctx context.Context, requestURL string, period rollupPeriod, date string) ([]byte, cacheResult, error) {
+	return cachedArtifact(ctx, dateCacheKey(period.CacheKey, date), dateCacheDuration(date), func(ctx context.Context) ([]byte, error) {
+		papers := collectRollupPapers(ctx, period, date)
+		if len(papers) == 0 {
+			return nil, fmt.Errorf("no papers found for %s roll-up", period.Name)
+		}
+
+		feed, err := marshalPaperRSS(papers, requestURL, defaultFeedLang, period.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate %s roll-up feed: %w", period.Name, err)
+		}
+		return feed, nil
+	})
+}
+
+// getCachedRollupSummary returns the LLM digest of a period's roll-up feed.
+func getCachedRollupSummary(ctx context.Context, requestURL string, period rollupPeriod, date string, lang string) ([]byte, cacheResult, error) {
+	key := dateCacheKey(langCacheKey(period.SummaryCacheKey, lang, defaultSummaryLang), date)
+	return cachedArtifact(ctx, key, dateCacheDuration(date), func(ctx context.Context) ([]byte, error) {
+		return generateRollupSummary(ctx, requestURL, period, date, lang)
+	})
+}
+
+// generateRollupSummary summarizes a period's roll-up feed with the LLM.
+func generateRollupSummary(ctx context.Context, requestURL string, period rollupPeriod, date string, lang string) ([]byte, error) {
+	feedBytes, _, err := getCachedRollupFeed(ctx, requestURL, period, date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s roll-up feed for summary: %w", period.Name, err)
+	}
+
+	markdown, err := parseRSSToMarkdown(string(feedBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s roll-up feed to markdown: %w", period.Name, err)
+	}
+
+	summaryContent, err := summarizeWithLLM(ctx, markdown, lang)
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize %s roll-up with LLM: %w", period.Name, err)
+	}
+	summaryContent = checkSummaryCitations(summaryContent, feedBytes)
+
+	end := listingTime(date)
+	return marshalSummaryRSS(summaryContent, requestURL, lang,
+		fmt.Sprintf(translate(lang, period.Name+".summary"), localizedDate(lang, end)),
+		langCacheKey(fmt.Sprintf("summary-%s-%s", period.Name, end.Format(dateLayout)), lang, defaultSummaryLang),
+		end)
+}
+
+// Conversation represents the structure of a podcast conversation
+type ConversationData struct {
+	Conversation []DialogueEntry `json:"conversation"`
+	// Model records which LLM wrote the conversation
+	Model string `json:"model,omitempty"`
+}
+
+type DialogueEntry struct {
+	Speaker string `json:"speaker"`
+	Text    string `json:"text"`
+	// Paper is the title of the paper under discussion, empty for intros and transitions
+	Paper string `json:"paper,omitempty"`
+}
+
+// ShowConfig describes the podcast cast. It is read from the JSON file named by
+// SHOW_CONFIG, or defaults to Brian and Jenny.
+type ShowConfig struct {
+	Hosts []ShowHost `json:"hosts"`
+}
+
+// ShowHost is one podcast host: how the model should play them and how they sound
+type ShowHost struct {
+	Name string `json:"name"`
+	// Persona is added to the conversation prompt to shape how the host speaks
+	Persona string `json:"persona,omitempty"`
+	Voice   string `json:"voice"`
+	// Provider is the text-to-speech service that renders the host's voice
+	Provider string `json:"provider,omitempty"`
+}
+
+// Text-to-speech providers a host can use
+const (
+	ttsProviderDeepInfra = "deepinfra"
+	ttsProviderOpenAI    = "openai"
+	ttsProviderCommand   = "command"
+)
+
+const (
+	minShowHosts = 1
+	maxShowHosts = 4
+)
+
+var defaultShow = ShowConfig{
+	Hosts: []ShowHost{
+		{Name: "Brian", Voice: "am_michael", Provider: ttsProviderDeepInfra},
+		{Name: "Jenny", Voice: "af_bella", Provider: ttsProviderDeepInfra},
+	},
+}
+
+// initShow loads the podcast cast from SHOW_CONFIG, keeping the default cast if the
+// file is missing or invalid.
+func initShow() {
+	path := os.Getenv("SHOW_CONFIG")
+	if path == "" {
+		return
+	}
+
+	config, err := loadShowConfig(path)
+	if err != nil {
+		logger.Error("Invalid show config, using default hosts", "path", path, "error", err)
+		return
+	}
+	show = config
+	logger.Info("Loaded show config", "path", path, "hosts", show.speakers())
+}
+
+func loadShowConfig(path string) (ShowConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ShowConfig{}, fmt.Errorf("failed to read show config: %w", err)
+	}
+
+	var config ShowConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return ShowConfig{}, fmt.Errorf("failed to parse show config: %w", err)
+	}
+	if n := len(config.Hosts); n < minShowHosts || n > maxShowHosts {
+		return ShowConfig{}, fmt.Errorf("show needs %d to %d hosts, got %d", minShowHosts, maxShowHosts, n)
+	}
+
+	seen := make(map[string]bool, len(config.Hosts))
+	for i := range config.Hosts {
+		host := &config.Hosts[i]
+		host.Name = strings.TrimSpace(host.Name)
+		if host.Name == "" {
+			return ShowConfig{}, fmt.Errorf("host %d has no name", i+1)
+		}
+		if seen[host.Name] {
+			return ShowConfig{}, fmt.Errorf("host %q is listed twice", host.Name)
+		}
+		seen[host.Name] = true
+		if host.Voice == "" {
+			return ShowConfig{}, fmt.Errorf("host %q has no voice", host.Name)
+		}
+		if host.Provider == "" {
+			host.Provider = ttsProviderDeepInfra
+		}
+		switch host.Provider {
+		case ttsProviderDeepInfra, ttsProviderOpenAI, ttsProviderCommand:
+		default:
+			return ShowConfig{}, fmt.Errorf("host %q uses unknown TTS provider %q", host.Name, host.Provider)
+		}
+	}
+	return config, nil
+}
+
+// speakers lists the host names in order.
+func (s ShowConfig) speakers() []string {
+	names := make([]string, len(s.Hosts))
+	for i, host := range s.Hosts {
+		names[i] = host.Name
+	}
+	return names
 }
 
-func generateSummaryRSS(summary string, requestURL string) ([]byte, error) {
-	now := time.Now().UTC()
+// host looks up a host by speaker name.
+func (s ShowConfig) host(name string) (ShowHost, bool) {
+	for _, host := range s.Hosts {
+		if host.Name == name {
+			return host, true
+		}
+	}
+	return ShowHost{}, false
+}
 
-	// Ensure the summary is properly wrapped in a div for better HTML structure
-	summary = fmt.Sprintf("<div>%s</div>", summary)
+// cast describes the hosts for the conversation prompt, e.g. "two experts, Brian and Jenny".
+func (s ShowConfig) cast() string {
+	names := s.speakers()
+	switch len(names) {
+	case 1:
+		return "a single host, " + names[0] + ", speaking directly to the listener"
+	case 2:
+		return "two experts, " + names[0] + " and " + names[1]
+	default:
+		count := map[int]string{3: "three", 4: "four"}[len(names)]
+		return count + " experts, " + strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+	}
+}
 
-	item := Item{
-		Title:       "AI Research Papers Summary for " + now.Format("January 2, 2006"),
-		Link:        liveURL,
-		Description: CDATA{Text: summary},
-		PubDate:     now.Format(time.RFC1123Z),
-		GUID: GUID{
-			IsPermaLink: false,
-			Text:        fmt.Sprintf("summary-%s", now.Format("2006-01-02")),
-		},
+// personas describes each host that has a persona, one per line.
+func (s ShowConfig) personas() string {
+	var b strings.Builder
+	for _, host := range s.Hosts {
+		if host.Persona != "" {
+			b.WriteString(fmt.Sprintf("        - %s: %s\n", host.Name, host.Persona))
+		}
 	}
+	if b.Len() == 0 {
+		return ""
+	}
+	return "\n        Meet the hosts:\n" + b.String() + "\n"
+}
 
-	rss := RSS{
-		Version: "2.0",
-		XMLNS:   "http://www.w3.org/2005/Atom",
-		Channel: Channel{
-			Title:         "Takara TLDR",
-			Link:          liveURL,
-			Description:   "Daily summaries of AI research papers from takara.ai",
-			LastBuildDate: now.Format(time.RFC1123Z),
-			AtomLink: AtomLink{
-				Href: requestURL,
-				Rel:  "self",
-				Type: "application/rss+xml",
+// minConversationTurns is the shortest conversation accepted from the model
+const minConversationTurns = 6
+
+// maxConversationPapers is how many of a paper feed's papers a conversation must cover
+const maxConversationPapers = 5
+
+// conversationSchema describes ConversationData for providers with structured output.
+func conversationSchema() JSONSchema {
+	schema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"conversation": map[string]any{
+				"type":     "array",
+				"minItems": minConversationTurns,
+				"items": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"speaker": map[string]any{"type": "string", "enum": show.speakers()},
+						"text":    map[string]any{"type": "string", "minLength": 1},
+						"paper":   map[string]any{"type": "string"},
+					},
+					"required":             []string{"speaker", "text", "paper"},
+					"additionalProperties": false,
+				},
 			},
-			Items: []Item{item},
 		},
+		"required":             []string{"conversation"},
+		"additionalProperties": false,
 	}
+	data, _ := json.Marshal(schema)
+	return JSONSchema{Name: "podcast_conversation", Schema: data}
+}
 
-	// Add XML header and proper encoding
-	output, err := xml.MarshalIndent(rss, "", "  ")
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal summary RSS: %w", err)
-	}
-
-	// Prepend the XML header
-	return append([]byte(xml.Header), output...), nil
+// ConversationValidationError lists everything wrong with a generated conversation, so
+// the model can be told what to fix.
+type ConversationValidationError struct {
+	Problems []string
 }
 
-// getCachedSummary retrieves the summary from cache or generates it if missed.
-// It now accepts a context for Redis operations and summary generation.
-func getCachedSummary(ctx context.Context, requestURL string) ([]byte, error) {
-	if !redisConnected {
-		logger.Warn("Redis not connected, generating summary directly")
-		return generateSummaryDirect(ctx, requestURL)
-	}
+func (e *ConversationValidationError) Error() string {
+	return "invalid conversation: " + strings.Join(e.Problems, "; ")
+}
 
-	// Try to get from cache first
-	cachedData, err := rdb.Get(ctx, summaryCacheKey).Bytes()
-	if err == nil {
-		logger.Info("Summary cache hit", "key", summaryCacheKey)
-		return cachedData, nil
-	} else if !errors.Is(err, redis.Nil) {
-		logger.Warn("Redis Get failed for summary, generating summary directly", "key", summaryCacheKey, "error", err)
+// validateConversation checks speakers, turn text and length, and that every paper
+// is discussed.
+func validateConversation(conversation *ConversationData, papers []string) error {
+	var problems []string
+	if n := len(conversation.Conversation); n < minConversationTurns {
+		problems = append(problems, fmt.Sprintf("conversation has %d turns, at least %d are required", n, minConversationTurns))
 	}
 
-	// Cache miss or Redis error, generate new summary
-	logger.Info("Summary cache miss, generating new summary")
-	summary, err := generateSummaryDirect(ctx, requestURL)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate summary directly after cache miss: %w", err)
+	for i, entry := range conversation.Conversation {
+		if _, ok := show.host(entry.Speaker); !ok {
+			problems = append(problems, fmt.Sprintf("turn %d has unknown speaker %q, allowed speakers are %s", i+1, entry.Speaker, strings.Join(show.speakers(), ", ")))
+		}
+		if strings.TrimSpace(entry.Text) == "" {
+			problems = append(problems, fmt.Sprintf("turn %d has empty text", i+1))
+		}
 	}
 
-	// Cache the new summary if Redis is connected
-	if redisConnected {
-		err = rdb.Set(ctx, summaryCacheKey, summary, cacheDuration).Err()
-		if err != nil {
-			logger.Warn("Failed to cache summary", "key", summaryCacheKey, "error", err)
-		} else {
-			logger.Info("Successfully cached new summary")
+	for _, title := range papers {
+		if !conversationCovers(conversation, title) {
+			problems = append(problems, fmt.Sprintf("paper %q is not discussed", title))
 		}
 	}
 
-	return summary, nil
+	if len(problems) > 0 {
+		return &ConversationValidationError{Problems: problems}
+	}
+	return nil
 }
 
-// generateSummaryDirect generates the summary by getting feed, parsing, and calling LLM.
-// It now accepts a context to pass down the call chain.
-func generateSummaryDirect(ctx context.Context, requestURL string) ([]byte, error) {
-	// Get the feed content, passing context
-	// This now correctly uses the feed cache if available, or generates directly.
-	feedBytes, err := getCachedFeed(ctx, requestURL)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get feed for summary generation: %w", err)
+// conversationCovers reports whether some turn is tagged with, or mentions, the paper.
+// A tag must name the paper exactly, so a tag like "AI" can't stand in for every title.
+func conversationCovers(conversation *ConversationData, title string) bool {
+	want := normalizeHeading(title)
+	for _, entry := range conversation.Conversation {
+		if normalizeHeading(entry.Paper) == want {
+			return true
+		}
+		if strings.Contains(normalizeHeading(entry.Text), want) {
+			return true
+		}
 	}
+	return false
+}
 
-	// Convert feed to markdown (no context needed for this part)
-	markdown, err := parseRSSToMarkdown(string(feedBytes))
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse RSS to markdown for summary generation: %w", err)
+// conversationPapers lists the paper titles a conversation must cover. The source is
+// either a summary feed, whose papers are the ones its briefing cites, or a paper feed,
+// of which only the maxConversationPapers most upvoted fit in a short episode.
+func conversationPapers(text string) []string {
+	var rss RSS
+	if err := xml.Unmarshal([]byte(text), &rss); err != nil {
+		return nil
 	}
 
-	// Summarize with LLM, passing context
-	summaryContent, err := summarizeWithLLM(ctx, markdown)
-	if err != nil {
-		return nil, fmt.Errorf("failed to summarize markdown with LLM: %w", err)
+	var titles []string
+	var papers []Item
+	for _, item := range rss.Channel.Items {
+		if item.Link != liveURL {
+			papers = append(papers, item)
+			continue
+		}
+		doc, err := html.Parse(strings.NewReader(item.Description.Text))
+		if err != nil {
+			continue
+		}
+		var walk func(*html.Node)
+		walk = func(n *html.Node) {
+			if n.Type == html.ElementNode && n.Data == "a" {
+				if title := strings.TrimSpace(extractText(n)); title != "" && !slices.Contains(titles, title) {
+					titles = append(titles, title)
+				}
+			}
+			for c := n.FirstChild; c != nil; c = c.NextSibling {
+				walk(c)
+			}
+		}
+		walk(doc)
 	}
 
-	// Use the original requestURL for the summary RSS self-link
-	return generateSummaryRSS(summaryContent, requestURL)
+	upvotes := func(item Item) int {
+		n, _ := strconv.Atoi(item.category(categoryUpvotes))
+		return n
+	}
+	slices.SortStableFunc(papers, func(a, b Item) int { return upvotes(b) - upvotes(a) })
+	for _, item := range papers[:min(len(papers), maxConversationPapers)] {
+		titles = append(titles, strings.TrimSpace(item.Title))
+	}
+	return titles
 }
 
-// Conversation represents the structure of a podcast conversation
-type ConversationData struct {
-	Conversation []DialogueEntry `json:"conversation"`
+// decodeJSONResponse reads the first JSON object in a model answer that decodes into v
+// and satisfies ok. Each candidate is read with a streaming decoder, so nested objects and
+// braces inside strings are handled, and any prose around the JSON is ignored.
+func decodeJSONResponse(content string, v any, ok func() bool) error {
+	lastErr := errors.New("no JSON object found in response")
+	for i := strings.IndexByte(content, '{'); i >= 0; {
+		var raw json.RawMessage
+		if err := json.NewDecoder(strings.NewReader(content[i:])).Decode(&raw); err != nil {
+			lastErr = fmt.Errorf("failed to parse JSON: %w", err)
+		} else if err := json.Unmarshal(raw, v); err == nil && ok() {
+			return nil
+		}
+
+		next := strings.IndexByte(content[i+1:], '{')
+		if next < 0 {
+			break
+		}
+		i += next + 1
+	}
+	return lastErr
 }
 
-type DialogueEntry struct {
-	Speaker string `json:"speaker"`
-	Text    string `json:"text"`
+// decodeConversation reads the first JSON object holding a conversation from a model
+// answer.
+func decodeConversation(content string) (*ConversationData, error) {
+	var conversation ConversationData
+	if err := decodeJSONResponse(content, &conversation, func() bool { return conversation.Conversation != nil }); err != nil {
+		return nil, fmt.Errorf("failed to parse conversation JSON: %w", err)
+	}
+	return &conversation, nil
 }
 
 func extractConversation(ctx context.Context, text string, maxRetries int) (*ConversationData, error) {
-	var lastErr error
+	client, err := newLLMClient(llmTaskConversation)
+	if err != nil {
+		return nil, err
+	}
+
+	papers := conversationPapers(text)
+	messages := []Message{
+		{
+			Role:    "user",
+			Content: conversationPrompt(text, papers),
+		},
+	}
 
+	var lastErr error
 	for attempt := 1; attempt <= maxRetries; attempt++ {
 		logger.Info("Attempting to generate conversation", "attempt", attempt, "maxRetries", maxRetries)
 
@@ -893,7 +4834,7 @@ func extractConversation(ctx context.Context, text string, maxRetries int) (*Con
 		attemptCtx, cancel := context.WithTimeout(ctx, llmTimeout)
 		defer cancel()
 
-		conversation, err := tryGenerateConversation(attemptCtx, text)
+		conversation, content, err := tryGenerateConversation(attemptCtx, client, messages, papers)
 		if err == nil {
 			return conversation, nil
 		}
@@ -904,6 +4845,17 @@ func extractConversation(ctx context.Context, text string, maxRetries int) (*Con
 			"error", err,
 			"remainingRetries", maxRetries-attempt)
 
+		// Show the model what was wrong with its answer so the retry can fix it
+		var validationErr *ConversationValidationError
+		if errors.As(err, &validationErr) {
+			messages = append(messages[:1],
+				Message{Role: "assistant", Content: content},
+				Message{Role: "user", Content: "That conversation is not valid:\n- " + strings.Join(validationErr.Problems, "\n- ") +
+					"\n\nReturn the complete corrected conversation in the same JSON format."},
+			)
+			continue
+		}
+
 		if attempt < maxRetries {
 			// Exponential backoff with jitter
 			backoff := time.Duration(attempt*2) * time.Second
@@ -920,273 +4872,930 @@ func extractConversation(ctx context.Context, text string, maxRetries int) (*Con
 	return nil, fmt.Errorf("failed to generate conversation after %d attempts: %w", maxRetries, lastErr)
 }
 
-func tryGenerateConversation(ctx context.Context, text string) (*ConversationData, error) {
-
-	apiURL := "https://router.huggingface.co/together/v1/chat/completions"
-	apiKey := os.Getenv("HF_API_KEY")
-
-	if apiKey == "" {
-		return nil, fmt.Errorf("HF_API_KEY environment variable is not set")
+func conversationPrompt(text string, papers []string) string {
+	var required strings.Builder
+	for _, title := range papers {
+		required.WriteString("        - " + title + "\n")
 	}
-
-	prompt := fmt.Sprintf(`Welcome to Daily Papers! Today, we're diving into the latest AI research in an engaging and 
+	return fmt.Sprintf(`Welcome to Daily Papers! Today, we're diving into the latest AI research in an engaging and 
         informative discussion. The goal is to make it a **bite-sized podcast** that's **engaging, natural, and insightful** while covering 
         the key points of each paper.
 
         Here are today's research papers:
         %s
 
-        Convert this into a **conversational podcast-style discussion** between two experts, Brian and Jenny. 
-        Ensure the conversation:
+        Convert this into a **conversational podcast-style discussion** between %s. 
+%s        Ensure the conversation:
         1. Flows naturally with realistic back-and-forth dialogue
         2. Uses casual phrasing and occasional filler words (like "um", "you know")
         3. Maintains professional insights while being engaging
-        4. Covers each paper meaningfully but concisely
-        5. Focuses on practical implications and key findings
+        4. Covers each of these papers meaningfully but concisely:
+%s        5. Focuses on practical implications and key findings
         6. Keeps a dynamic pace with natural transitions
 		7. Avoid's Host calling each other by name, just "you" and "I".
 
-        Return the conversation in this exact JSON format:
+        Return the conversation in this exact JSON format, with at least %d turns. Set "paper" to the
+        exact title of the paper a turn discusses, or "" for the intro, transitions and sign-off:
         {
             "conversation": [
-                {"speaker": "Brian", "text": ""},
-                {"speaker": "Jenny", "text": ""}
+%s
             ]
-        }`, text)
+        }`, text, show.cast(), show.personas(), required.String(), minConversationTurns, conversationExample())
+}
 
-	request := LLMRequest{
-		Model: "Qwen/Qwen2.5-72B-Instruct-Turbo",
-		Messages: []Message{
-			{
-				Role:    "user",
-				Content: prompt,
-			},
-		},
-		MaxTokens:   4096,
-		Temperature: 0.7,
-		TopP:        0.95,
-		Stream:      false,
+// conversationExample shows one turn per host in the prompt's JSON format.
+func conversationExample() string {
+	turns := make([]string, len(show.Hosts))
+	for i, host := range show.Hosts {
+		turns[i] = fmt.Sprintf(`                {"speaker": %q, "text": "", "paper": ""}`, host.Name)
 	}
+	return strings.Join(turns, ",\n")
+}
 
-	requestBody, err := json.Marshal(request)
+// tryGenerateConversation asks the model for a conversation and validates it. The raw
+// answer is returned alongside validation errors so it can be sent back for correction.
+func tryGenerateConversation(ctx context.Context, client LLMClient, messages []Message, papers []string) (*ConversationData, string, error) {
+	completion, err := client.CompleteJSON(ctx, messages, conversationSchema())
+	if err != nil {
+		return nil, "", err
+	}
+
+	conversation, err := decodeConversation(completion.Content)
+	if err != nil {
+		return nil, completion.Content, &ConversationValidationError{Problems: []string{err.Error()}}
+	}
+	if err := validateConversation(conversation, papers); err != nil {
+		return nil, completion.Content, err
+	}
+	conversation.Model = completion.Model
+
+	return conversation, completion.Content, nil
+}
+
+func generatePodcastConversation(ctx context.Context, text string, date string) (string, error) {
+	conversation, err := extractConversation(ctx, text, 3)
+	if err != nil {
+		return "", fmt.Errorf("failed to extract conversation: %w", err)
+	}
+
+	// Convert back to JSON string
+	result, err := json.MarshalIndent(conversation, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal conversation: %w", err)
+	}
+
+	archivePut(ctx, kindConversations, date, result)
+
+	// Generate audio podcast from the conversation
+	// filename, err := generateaudiopodcast(ctx, string(result))
+	// if err != nil {
+	// 	return "", fmt.Errorf("failed to generate audio podcast: %w", err)
+	// }
+	// logger.Info("Generated audio podcast", "filename", filename)
+	// Save the audio content to a file or return it as needed
+
+	return string(result), nil
+}
+
+func getcachedconversation(ctx context.Context, text string, date string) (string, cacheResult, error) {
+	// The episode's audio and timings were recorded from this conversation, so it is
+	// only replaced together with them by the scheduled update
+	key := dateCacheKey(conversationCacheKey, date)
+	conversation, result, err := pinnedArtifact(ctx, key, dateCacheDuration(date), func(ctx context.Context) ([]byte, error) {
+		// Fall back to the archive before asking the LLM again
+		archived, err := archiveGet(ctx, kindConversations, date)
+		if err == nil {
+			logger.Info("Conversation found in archive", "date", archiveDate(date))
+			return archived, nil
+		} else if !errors.Is(err, errNotArchived) {
+			logger.Warn("Failed to read archived conversation", "date", date, "error", err)
+		}
+
+		conversation, err := generatePodcastConversation(ctx, text, date)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate podcast conversation: %w", err)
+		}
+		return []byte(conversation), nil
+	})
+	return string(conversation), result, err
+}
+
+// TTSProvider turns one line of dialogue into MP3 audio in the given voice
+type TTSProvider interface {
+	Synthesize(ctx context.Context, text, voice string) ([]byte, error)
+}
+
+// TTSStatusError is returned when a speech endpoint answers with a non-200 status
+type TTSStatusError struct {
+	URL        string
+	StatusCode int
+	Body       string
+}
+
+func (e *TTSStatusError) Error() string {
+	return fmt.Sprintf("HTTP error %d from TTS API at %s: %s", e.StatusCode, e.URL, e.Body)
+}
+
+// openAISpeechProvider talks to any server implementing the OpenAI audio speech API,
+// such as DeepInfra's hosted Kokoro.
+type openAISpeechProvider struct {
+	baseURL string
+	model   string
+	apiKey  string
+	client  *http.Client
+}
+
+func (p *openAISpeechProvider) Synthesize(ctx context.Context, text, voice string) ([]byte, error) {
+	apiURL := p.baseURL + "/audio/speech"
+
+	requestBody := map[string]interface{}{
+		"model":           p.model,
+		"input":           text,
+		"voice":           voice,
+		"response_format": "mp3",
+	}
+	jsonBody, err := json.Marshal(requestBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal TTS request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", apiURL, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create TTS request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request to TTS API at %s: %w", apiURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, &TTSStatusError{URL: apiURL, StatusCode: resp.StatusCode, Body: string(bodyBytes)}
+	}
+
+	audio, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read TTS audio: %w", err)
+	}
+	return audio, nil
+}
+
+// commandTTSProvider runs a local speech engine. The text is written to the command's
+// stdin and MP3 audio is read from its stdout; {voice} in the arguments is replaced
+// with the host's voice.
+type commandTTSProvider struct {
+	args []string
+}
+
+func (p *commandTTSProvider) Synthesize(ctx context.Context, text, voice string) ([]byte, error) {
+	args := make([]string, len(p.args))
+	for i, arg := range p.args {
+		args[i] = strings.ReplaceAll(arg, "{voice}", voice)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdin = strings.NewReader(text)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("TTS command %s failed: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	if stdout.Len() == 0 {
+		return nil, fmt.Errorf("TTS command %s produced no audio", args[0])
+	}
+	return stdout.Bytes(), nil
+}
+
+// newTTSProvider builds a provider by name from its environment configuration:
+//   - deepinfra: Kokoro on DeepInfra, using DEEPINFRA_API_KEY
+//   - openai: TTS_OPENAI_BASE_URL, TTS_OPENAI_MODEL and TTS_OPENAI_API_KEY
+//   - command: TTS_COMMAND, e.g. "piper-mp3 --voice {voice}"
+func newTTSProvider(name string) (TTSProvider, error) {
+	client := &http.Client{Timeout: ttsTimeout}
+	switch name {
+	case ttsProviderDeepInfra:
+		apiKey := os.Getenv("DEEPINFRA_API_KEY")
+		if apiKey == "" {
+			return nil, fmt.Errorf("DEEPINFRA_API_KEY environment variable is not set")
+		}
+		return &openAISpeechProvider{
+			baseURL: "https://api.deepinfra.com/v1/openai",
+			model:   "hexgrad/Kokoro-82M",
+			apiKey:  apiKey,
+			client:  client,
+		}, nil
+	case ttsProviderOpenAI:
+		baseURL := strings.TrimSuffix(os.Getenv("TTS_OPENAI_BASE_URL"), "/")
+		if baseURL == "" {
+			baseURL = "https://api.openai.com/v1"
+		}
+		model := os.Getenv("TTS_OPENAI_MODEL")
+		if model == "" {
+			model = "tts-1"
+		}
+		return &openAISpeechProvider{
+			baseURL: baseURL,
+			model:   model,
+			apiKey:  os.Getenv("TTS_OPENAI_API_KEY"),
+			client:  client,
+		}, nil
+	case ttsProviderCommand:
+		args := strings.Fields(os.Getenv("TTS_COMMAND"))
+		if len(args) == 0 {
+			return nil, fmt.Errorf("TTS_COMMAND environment variable is not set")
+		}
+		return &commandTTSProvider{args: args}, nil
+	}
+	return nil, fmt.Errorf("unknown TTS provider %q", name)
+}
+
+// isRetryableTTSError reports whether a failed synthesis is worth another attempt.
+// Requests the provider rejected outright will fail the same way again.
+func isRetryableTTSError(err error) bool {
+	var statusErr *TTSStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode >= 500 || statusErr.StatusCode == http.StatusTooManyRequests
+	}
+	return true
+}
+
+// synthesizeSegment renders one dialogue line, retrying transient failures with backoff.
+func synthesizeSegment(ctx context.Context, provider TTSProvider, text, voice string) ([]byte, error) {
+	var lastErr error
+	for attempt := 1; attempt <= ttsMaxAttempts; attempt++ {
+		// Each request gets its own deadline so one stuck line can't stall the episode
+		attemptCtx, cancel := context.WithTimeout(ctx, ttsTimeout)
+		audio, err := provider.Synthesize(attemptCtx, text, voice)
+		cancel()
+		if err == nil {
+			return audio, nil
+		}
+
+		lastErr = err
+		if ctx.Err() != nil || !isRetryableTTSError(err) || attempt == ttsMaxAttempts {
+			break
+		}
+		logger.Warn("TTS request failed, retrying", "attempt", attempt, "voice", voice, "error", err)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Duration(attempt) * time.Second):
+		}
+	}
+	return nil, lastErr
+}
+
+// generateaudiopodcast voices a conversation and returns the episode audio along with
+// the start and end time of each line.
+func generateaudiopodcast(ctx context.Context, text string, date string) ([]byte, []SegmentTiming, error) {
+	// Parse the conversation JSON
+	var conversation ConversationData
+	if err := json.Unmarshal([]byte(text), &conversation); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse conversation: %w", err)
+	}
+
+	// Resolve every speaker's voice up front so a bad line fails before any audio is made
+	providers := make(map[string]TTSProvider)
+	hosts := make([]ShowHost, len(conversation.Conversation))
+	for i, entry := range conversation.Conversation {
+		host, ok := show.host(entry.Speaker)
+		if !ok {
+			return nil, nil, fmt.Errorf("turn %d has unknown speaker %q", i+1, entry.Speaker)
+		}
+		if _, ok := providers[host.Provider]; !ok {
+			provider, err := newTTSProvider(host.Provider)
+			if err != nil {
+				return nil, nil, err
+			}
+			providers[host.Provider] = provider
+		}
+		hosts[i] = host
+	}
+
+	// Synthesize lines in a bounded pool; the first failure cancels the rest
+	poolCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	segments := make([][]byte, len(conversation.Conversation))
+	errs := make([]error, len(conversation.Conversation))
+	sem := make(chan struct{}, max(envInt("TTS_CONCURRENCY", defaultTTSConcurrency), 1))
+	var wg sync.WaitGroup
+	for i, entry := range conversation.Conversation {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			if poolCtx.Err() != nil {
+				errs[i] = poolCtx.Err()
+				return
+			}
+
+			segments[i], errs[i] = synthesizeSegment(poolCtx, providers[hosts[i].Provider], entry.Text, hosts[i].Voice)
+			if errs[i] != nil {
+				cancel()
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil && !errors.Is(err, context.Canceled) {
+			return nil, nil, fmt.Errorf("failed to synthesize turn %d: %w", i+1, err)
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, nil, fmt.Errorf("podcast synthesis cancelled: %w", err)
+	}
+
+	// Time each line from its frames, then reassemble the segments in dialogue order
+	// as one properly tagged stream
+	timings := make([]SegmentTiming, len(segments))
+	var start time.Duration
+	for i, entry := range conversation.Conversation {
+		end := start + mp3Duration(segments[i])
+		timings[i] = SegmentTiming{
+			Speaker: entry.Speaker,
+			Text:    entry.Text,
+			Paper:   entry.Paper,
+			Start:   start.Seconds(),
+			End:     end.Seconds(),
+		}
+		start = end
+	}
+
+	day := listingTime(date)
+	tag := podcastTag{
+		Title:    "Daily Papers for " + day.Format("January 2, 2006"),
+		Album:    podcastTitle,
+		Artist:   podcastAuthor,
+		Date:     day,
+		Chapters: podcastChapters(timings),
+	}
+	tag.Artwork, tag.ArtworkMIME = fetchPodcastArtwork(ctx)
+	audio, duration, err := muxMP3(segments, tag)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
+		return nil, nil, fmt.Errorf("failed to assemble podcast audio: %w", err)
+	}
+	logger.Info("Assembled podcast audio", "segments", len(segments), "chapters", len(tag.Chapters), "duration", duration, "size", len(audio))
+
+	return audio, timings, nil
+}
+
+// podcastTag is the metadata written to an episode's ID3v2 tag
+type podcastTag struct {
+	Title       string
+	Album       string
+	Artist      string
+	Date        time.Time
+	Duration    time.Duration
+	Artwork     []byte
+	ArtworkMIME string
+	Chapters    []PodcastChapter
+}
+
+// mp3Frame is a parsed MPEG Layer III frame header
+type mp3Frame struct {
+	header     uint32
+	mpeg1      bool
+	mono       bool
+	size       int
+	samples    int
+	sampleRate int
+	bitrate    int
+}
+
+var (
+	mp3BitratesV1   = [16]int{0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 0}
+	mp3BitratesV2   = [16]int{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160, 0}
+	mp3SampleRates  = map[uint32][3]int{3: {44100, 48000, 32000}, 2: {22050, 24000, 16000}, 0: {11025, 12000, 8000}}
+	errNoMP3Frames  = errors.New("no MP3 frames found")
+	errMP3Format    = errors.New("MP3 format differs from the first segment")
+	xingHeaderFlags = uint32(0x7) // frame count, byte count and seek table
+)
+
+// parseMP3Frame reads the frame header at the start of b. Only Layer III, which every
+// speech provider produces, is supported.
+func parseMP3Frame(b []byte) (mp3Frame, bool) {
+	if len(b) < 4 || b[0] != 0xFF || b[1]&0xE0 != 0xE0 {
+		return mp3Frame{}, false
+	}
+	header := binary.BigEndian.Uint32(b)
+	version := header >> 19 & 0x3
+	layer := header >> 17 & 0x3
+	rates, ok := mp3SampleRates[version]
+	rateIndex := header >> 10 & 0x3
+	if !ok || layer != 1 || rateIndex == 3 {
+		return mp3Frame{}, false
+	}
+
+	f := mp3Frame{
+		header:     header,
+		mpeg1:      version == 3,
+		mono:       header>>6&0x3 == 3,
+		sampleRate: rates[rateIndex],
+	}
+	padding := int(header >> 9 & 0x1)
+	if f.mpeg1 {
+		f.bitrate = mp3BitratesV1[header>>12&0xF] * 1000
+		f.samples = 1152
+		f.size = 144*f.bitrate/f.sampleRate + padding
+	} else {
+		f.bitrate = mp3BitratesV2[header>>12&0xF] * 1000
+		f.samples = 576
+		f.size = 72*f.bitrate/f.sampleRate + padding
+	}
+	if f.bitrate == 0 {
+		return mp3Frame{}, false
 	}
+	return f, true
+}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", apiURL, bytes.NewBuffer(requestBody))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+// sideInfoSize is the length of the side information following the frame header,
+// which is where a Xing or Info header starts.
+func (f mp3Frame) sideInfoSize() int {
+	switch {
+	case f.mpeg1 && f.mono:
+		return 17
+	case f.mpeg1:
+		return 32
+	case f.mono:
+		return 9
+	default:
+		return 17
 	}
+}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+apiKey)
+// format is the header's MPEG version, sample rate and channel mode, which must stay
+// the same throughout a stream.
+func (f mp3Frame) format() uint32 {
+	return f.header & (0x3<<19 | 0x3<<10 | 0x3<<6)
+}
 
-	client := &http.Client{Timeout: llmTimeout}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
-	}
-	defer resp.Body.Close()
+func (f mp3Frame) channelMode() string {
+	return [4]string{"stereo", "joint stereo", "dual channel", "mono"}[f.header>>6&0x3]
+}
 
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("HTTP error %d: %s", resp.StatusCode, string(bodyBytes))
-	}
+func (f mp3Frame) duration() time.Duration {
+	return time.Duration(f.samples) * time.Second / time.Duration(f.sampleRate)
+}
 
-	var llmResp LLMResponse
-	if err := json.NewDecoder(resp.Body).Decode(&llmResp); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+// isVBRHeader reports whether a frame carries a Xing, Info or VBRI header rather than audio.
+func isVBRHeader(f mp3Frame, frame []byte) bool {
+	if off := 4 + f.sideInfoSize(); len(frame) >= off+4 {
+		if tag := string(frame[off : off+4]); tag == "Xing" || tag == "Info" {
+			return true
+		}
 	}
+	return len(frame) >= 40 && string(frame[36:40]) == "VBRI"
+}
 
-	if len(llmResp.Choices) == 0 || llmResp.Choices[0].Message.Content == "" {
-		return nil, fmt.Errorf("no valid content in response")
+// stripID3 removes a leading ID3v2 tag and a trailing ID3v1 tag.
+func stripID3(b []byte) []byte {
+	for len(b) >= 10 && string(b[:3]) == "ID3" {
+		size := int(b[6]&0x7F)<<21 | int(b[7]&0x7F)<<14 | int(b[8]&0x7F)<<7 | int(b[9]&0x7F)
+		size += 10
+		if b[5]&0x10 != 0 {
+			size += 10 // footer
+		}
+		if size > len(b) {
+			return nil
+		}
+		b = b[size:]
 	}
+	if len(b) >= 128 && string(b[len(b)-128:len(b)-125]) == "TAG" {
+		b = b[:len(b)-128]
+	}
+	return b
+}
 
-	content := llmResp.Choices[0].Message.Content
+// mp3Frames splits an MP3 stream into its audio frames, dropping tags, VBR headers
+// and any bytes between frames.
+func mp3Frames(b []byte) ([][]byte, []mp3Frame) {
+	b = stripID3(b)
+	var frames [][]byte
+	var headers []mp3Frame
+	for pos := 0; pos+4 <= len(b); {
+		f, ok := parseMP3Frame(b[pos:])
+		if !ok || pos+f.size > len(b) {
+			pos++
+			continue
+		}
+		frame := b[pos : pos+f.size]
+		pos += f.size
+		if len(frames) == 0 && isVBRHeader(f, frame) {
+			continue
+		}
+		frames = append(frames, frame)
+		headers = append(headers, f)
+	}
+	return frames, headers
+}
 
-	// Extract JSON using regex if needed
-	re := regexp.MustCompile(`\{(?:[^{}]|(?:\{[^{}]*\}))*\}`)
-	match := re.FindString(content)
-	if match == "" {
-		return nil, fmt.Errorf("no valid JSON found in response")
+// mp3Duration returns the playing time of an MP3 stream by counting its frames.
+func mp3Duration(b []byte) time.Duration {
+	_, headers := mp3Frames(b)
+	var d time.Duration
+	for _, f := range headers {
+		d += f.duration()
 	}
+	return d
+}
 
-	var conversation ConversationData
-	if err := json.Unmarshal([]byte(match), &conversation); err != nil {
-		return nil, fmt.Errorf("failed to parse conversation JSON: %w", err)
+// muxMP3 joins MP3 segments into one stream: each segment's tags and VBR headers are
+// dropped, and the result gets a single ID3v2 tag and a Xing header covering every
+// frame, so players see the right duration and can seek. It returns the exact duration.
+// Segments must share an MPEG version, sample rate and channel mode, since players
+// can't switch format mid-stream.
+func muxMP3(segments [][]byte, tag podcastTag) ([]byte, time.Duration, error) {
+	var frames [][]byte
+	var headers []mp3Frame
+	for i, segment := range segments {
+		f, h := mp3Frames(segment)
+		if len(f) == 0 {
+			return nil, 0, fmt.Errorf("segment %d: %w", i+1, errNoMP3Frames)
+		}
+		want := h[0]
+		if len(headers) > 0 {
+			want = headers[0]
+		}
+		for _, header := range h {
+			if header.format() != want.format() {
+				return nil, 0, fmt.Errorf("segment %d has %d Hz %s audio: %w", i+1, header.sampleRate, header.channelMode(), errMP3Format)
+			}
+		}
+		frames = append(frames, f...)
+		headers = append(headers, h...)
+	}
+	if len(frames) == 0 {
+		return nil, 0, errNoMP3Frames
 	}
 
-	// Validate conversation structure
-	if len(conversation.Conversation) == 0 {
-		return nil, fmt.Errorf("parsed JSON contains no conversation entries")
+	var duration time.Duration
+	audioBytes := 0
+	cbr := true
+	for i, f := range headers {
+		duration += f.duration()
+		audioBytes += len(frames[i])
+		cbr = cbr && f.bitrate == headers[0].bitrate
 	}
 
-	return &conversation, nil
+	xing := xingFrame(headers[0], frames, audioBytes, cbr)
+	tag.Duration = duration
+
+	var out bytes.Buffer
+	out.Grow(audioBytes + len(xing) + len(tag.Artwork) + 1024)
+	out.Write(id3Tag(tag))
+	out.Write(xing)
+	for _, frame := range frames {
+		out.Write(frame)
+	}
+	return out.Bytes(), duration, nil
 }
 
-func generatePodcastConversation(ctx context.Context, text string) (string, error) {
-	conversation, err := extractConversation(ctx, text, 3)
-	if err != nil {
-		return "", fmt.Errorf("failed to extract conversation: %w", err)
+// xingFrame builds a silent frame matching the stream's format that holds the frame
+// count, byte count and a 100-entry seek table.
+func xingFrame(first mp3Frame, frames [][]byte, audioBytes int, cbr bool) []byte {
+	// A fixed bitrate leaves room for the header at every sample rate:
+	// 128 kbps for MPEG-1 and 64 kbps for MPEG-2 and 2.5
+	bitrateIndex := uint32(9)
+	if !first.mpeg1 {
+		bitrateIndex = 8
+	}
+	header := first.header&^(0xF<<12|1<<9) | bitrateIndex<<12 | 1<<16 // no padding, no CRC
+	f, _ := parseMP3Frame(binary.BigEndian.AppendUint32(nil, header))
+
+	frame := make([]byte, f.size)
+	binary.BigEndian.PutUint32(frame, header)
+	off := 4 + f.sideInfoSize()
+	name := "Xing"
+	if cbr {
+		name = "Info"
+	}
+	copy(frame[off:], name)
+	binary.BigEndian.PutUint32(frame[off+4:], xingHeaderFlags)
+	binary.BigEndian.PutUint32(frame[off+8:], uint32(len(frames)))
+	total := len(frame) + audioBytes
+	binary.BigEndian.PutUint32(frame[off+12:], uint32(total))
+
+	// Seek table: the byte position of each percent of the duration, scaled to 0-255
+	offsets := make([]int, len(frames))
+	pos := len(frame)
+	for i, fr := range frames {
+		offsets[i] = pos
+		pos += len(fr)
 	}
+	toc := frame[off+16 : off+116]
+	for i := range toc {
+		toc[i] = byte(min(offsets[i*len(frames)/100]*256/total, 255))
+	}
+	return frame
+}
 
-	// Convert back to JSON string
-	result, err := json.MarshalIndent(conversation, "", "  ")
-	if err != nil {
-		return "", fmt.Errorf("failed to marshal conversation: %w", err)
+// id3Tag encodes an ID3v2.3 tag, the version podcast players support most widely.
+func id3Tag(tag podcastTag) []byte {
+	var frames bytes.Buffer
+	frame := func(id string, body []byte) []byte {
+		b := append([]byte(id), binary.BigEndian.AppendUint32(nil, uint32(len(body)))...)
+		return append(append(b, 0, 0), body...)
+	}
+	writeFrame := func(id string, body []byte) {
+		frames.Write(frame(id, body))
+	}
+	text := func(s string) []byte {
+		// UTF-16 with a byte order mark, so titles in any language survive
+		body := []byte{1, 0xFF, 0xFE}
+		for _, u := range utf16.Encode([]rune(s)) {
+			body = binary.LittleEndian.AppendUint16(body, u)
+		}
+		return body
+	}
+	latin1 := func(s string) []byte {
+		return append([]byte{0}, s...)
 	}
 
-	// Generate audio podcast from the conversation
-	// filename, err := generateaudiopodcast(ctx, string(result))
-	// if err != nil {
-	// 	return "", fmt.Errorf("failed to generate audio podcast: %w", err)
-	// }
-	// logger.Info("Generated audio podcast", "filename", filename)
-	// Save the audio content to a file or return it as needed
+	if tag.Title != "" {
+		writeFrame("TIT2", text(tag.Title))
+	}
+	if tag.Artist != "" {
+		writeFrame("TPE1", text(tag.Artist))
+	}
+	if tag.Album != "" {
+		writeFrame("TALB", text(tag.Album))
+	}
+	if !tag.Date.IsZero() {
+		writeFrame("TYER", latin1(tag.Date.Format("2006")))
+		writeFrame("TDAT", latin1(tag.Date.Format("0201")))
+	}
+	if tag.Duration > 0 {
+		writeFrame("TLEN", latin1(strconv.FormatInt(tag.Duration.Milliseconds(), 10)))
+	}
+	if len(tag.Artwork) > 0 {
+		body := latin1(tag.ArtworkMIME)
+		body = append(body, 0, 3, 0) // MIME terminator, front cover, empty description
+		writeFrame("APIC", append(body, tag.Artwork...))
+	}
 
-	// Cache the new conversation if Redis is connected
-	if redisConnected {
-		err = rdb.Set(ctx, conversationCacheKey, result, cacheDuration).Err()
-		if err != nil {
-			logger.Warn("Failed to cache conversation", "key", conversationCacheKey, "error", err)
-		} else {
-			logger.Info("Successfully cached new conversation")
+	// Chapters follow the ID3v2 chapter addendum: a table of contents listing
+	// one CHAP frame per chapter, each titled with an embedded TIT2 frame
+	if len(tag.Chapters) > 0 && len(tag.Chapters) < 256 {
+		toc := append([]byte("toc"), 0, 0x03, byte(len(tag.Chapters))) // top-level, ordered
+		for i := range tag.Chapters {
+			toc = append(toc, fmt.Sprintf("chp%d", i)...)
+			toc = append(toc, 0)
+		}
+		writeFrame("CTOC", toc)
+
+		for i, chapter := range tag.Chapters {
+			body := append([]byte(fmt.Sprintf("chp%d", i)), 0)
+			body = binary.BigEndian.AppendUint32(body, uint32(math.Round(chapter.StartTime*1000)))
+			body = binary.BigEndian.AppendUint32(body, uint32(math.Round(chapter.EndTime*1000)))
+			body = binary.BigEndian.AppendUint32(body, 0xFFFFFFFF) // no byte offsets
+			body = binary.BigEndian.AppendUint32(body, 0xFFFFFFFF)
+			writeFrame("CHAP", append(body, frame("TIT2", text(chapter.Title))...))
 		}
 	}
 
-	return string(result), nil
+	size := frames.Len()
+	header := []byte{'I', 'D', '3', 3, 0, 0,
+		byte(size >> 21 & 0x7F), byte(size >> 14 & 0x7F), byte(size >> 7 & 0x7F), byte(size & 0x7F)}
+	return append(header, frames.Bytes()...)
 }
 
-func getcachedconversation(ctx context.Context, text string) (string, error) {
-	// Check if Redis is connected
-	if redisConnected {
-		cachedData, err := rdb.Get(ctx, conversationCacheKey).Bytes()
-		if err == nil {
-			logger.Info("Conversation cache hit", "key", conversationCacheKey)
-			return string(cachedData), nil
-		} else if !errors.Is(err, redis.Nil) {
-			logger.Warn("Redis Get failed for conversation, generating conversation directly", "key", conversationCacheKey, "error", err)
-		} else {
-			logger.Info("Conversation cache miss, generating new conversation")
-		}
+// fetchPodcastArtwork downloads PODCAST_IMAGE_URL for embedding in episodes. Episodes
+// are still tagged without artwork if it can't be fetched.
+func fetchPodcastArtwork(ctx context.Context) ([]byte, string) {
+	imageURL := os.Getenv("PODCAST_IMAGE_URL")
+	if imageURL == "" {
+		return nil, ""
 	}
 
-	conversation, err := generatePodcastConversation(ctx, text)
+	req, err := http.NewRequestWithContext(ctx, "GET", imageURL, nil)
 	if err != nil {
-		return "", fmt.Errorf("failed to generate podcast conversation: %w", err)
+		logger.Warn("Failed to create artwork request", "url", imageURL, "error", err)
+		return nil, ""
 	}
-
-	return conversation, nil
-}
-
-func generateaudiopodcast(ctx context.Context, text string) ([]byte, error) {
-	// Parse the conversation JSON
-	var conversation ConversationData
-	if err := json.Unmarshal([]byte(text), &conversation); err != nil {
-		return nil, fmt.Errorf("failed to parse conversation: %w", err)
+	client := &http.Client{Timeout: scrapeTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		logger.Warn("Failed to fetch podcast artwork", "url", imageURL, "error", err)
+		return nil, ""
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		logger.Warn("Failed to fetch podcast artwork", "url", imageURL, "status", resp.StatusCode)
+		return nil, ""
 	}
 
-	apiKey := os.Getenv("DEEPINFRA_API_KEY")
-	if apiKey == "" {
-		return nil, fmt.Errorf("DEEPINFRA_API_KEY environment variable is not set")
+	image, err := io.ReadAll(io.LimitReader(resp.Body, maxArtworkSize+1))
+	if err != nil {
+		logger.Warn("Failed to read podcast artwork", "url", imageURL, "error", err)
+		return nil, ""
+	}
+	if len(image) > maxArtworkSize {
+		logger.Warn("Podcast artwork is too large to embed", "url", imageURL, "limit", maxArtworkSize)
+		return nil, ""
 	}
+	mime := resp.Header.Get("Content-Type")
+	if mime == "" {
+		mime = http.DetectContentType(image)
+	}
+	return image, mime
+}
 
-	url := "https://api.deepinfra.com/v1/openai/audio/speech"
+// podcastAudio is an episode's audio with its size, modification time and ETag for
+// range and conditional requests
+type podcastAudio struct {
+	Content io.ReadSeeker
+	Size    int64
+	ModTime time.Time
+	ETag    string
+}
 
-	// Create a buffer to store the audio data
-	var audioBuffer bytes.Buffer
+// generatedPodcastAudio wraps freshly generated audio, tagged by its content so
+// regenerated episodes get a new ETag.
+func generatedPodcastAudio(data []byte) *podcastAudio {
+	sum := sha256.Sum256(data)
+	return &podcastAudio{
+		Content: bytes.NewReader(data),
+		Size:    int64(len(data)),
+		ModTime: time.Now().UTC(),
+		ETag:    `"` + hex.EncodeToString(sum[:16]) + `"`,
+	}
+}
 
-	// Process each dialogue entry
-	for _, entry := range conversation.Conversation {
-		voice := "af_bella"
-		if entry.Speaker == "Jenny" {
-			voice = "af_bella"
-		} else if entry.Speaker == "Brian" {
-			voice = "am_michael"
-		}
+// Close releases the blob store stream of an archived episode.
+func (a *podcastAudio) Close() error {
+	if closer, ok := a.Content.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
 
-		// Prepare request body
-		requestBody := map[string]interface{}{
-			"model":           "hexgrad/Kokoro-82M",
-			"input":           entry.Text,
-			"voice":           voice,
-			"response_format": "mp3",
-		}
+// blobReader reads a stored object for http.ServeContent without downloading it
+// whole. The first Read after a seek opens the object from the current offset, so a
+// range request only fetches from the start of its range.
+type blobReader struct {
+	ctx    context.Context
+	store  BlobStore
+	key    string
+	size   int64
+	offset int64
+	body   io.ReadCloser
+}
 
-		jsonBody, err := json.Marshal(requestBody)
+func (r *blobReader) Read(p []byte) (int, error) {
+	if r.offset >= r.size {
+		return 0, io.EOF
+	}
+	if r.body == nil {
+		body, err := r.store.Open(r.ctx, r.key, r.offset)
 		if err != nil {
-			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+			return 0, err
 		}
+		r.body = body
+	}
+	n, err := r.body.Read(p)
+	r.offset += int64(n)
+	return n, err
+}
 
-		// Create request
-		req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonBody))
-		if err != nil {
-			return nil, fmt.Errorf("failed to create request: %w", err)
-		}
+func (r *blobReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += r.offset
+	case io.SeekEnd:
+		offset += r.size
+	default:
+		return 0, fmt.Errorf("invalid whence %d", whence)
+	}
+	if offset < 0 {
+		return 0, fmt.Errorf("invalid offset %d", offset)
+	}
+	if offset != r.offset {
+		r.Close()
+		r.offset = offset
+	}
+	return offset, nil
+}
+
+func (r *blobReader) Close() error {
+	if r.body == nil {
+		return nil
+	}
+	err := r.body.Close()
+	r.body = nil
+	return err
+}
 
-		// Set headers
-		req.Header.Set("Authorization", "Bearer "+apiKey)
-		req.Header.Set("Content-Type", "application/json")
+// servePodcastAudio answers byte-range and conditional requests for an episode.
+// Multipart responses aren't worth supporting for audio, so multi-range requests
+// are rejected.
+func servePodcastAudio(w http.ResponseWriter, r *http.Request, audio *podcastAudio) {
+	w.Header().Set("Content-Type", "audio/mpeg")
+	w.Header().Set("Content-Disposition", "inline; filename=\"daily-papers-podcast.mp3\"")
+	w.Header().Set("ETag", audio.ETag)
+	w.Header().Set("Accept-Ranges", "bytes")
+
+	if strings.Contains(r.Header.Get("Range"), ",") {
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes */%d", audio.Size))
+		http.Error(w, "Multiple ranges are not supported", http.StatusRequestedRangeNotSatisfiable)
+		return
+	}
 
-		// Make request
-		client := &http.Client{}
-		resp, err := client.Do(req)
-		if err != nil {
-			return nil, fmt.Errorf("failed to make request: %w", err)
-		}
-		defer resp.Body.Close()
+	// ServeContent handles single ranges (206 or 416), If-None-Match, If-Modified-Since
+	// and If-Range against the ETag and modification time set above
+	http.ServeContent(w, r, "daily-papers-podcast.mp3", audio.ModTime, audio.Content)
+}
 
-		// Check response status
-		if resp.StatusCode != http.StatusOK {
-			bodyBytes, _ := io.ReadAll(resp.Body)
-			return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(bodyBytes))
+// podcastRedirectURL returns the blob store URL of an archived episode, or "" if the
+// episode isn't archived yet or has to be served through the API.
+func podcastRedirectURL(ctx context.Context, date string) string {
+	manifest, err := getManifest(ctx)
+	if err != nil {
+		logger.Warn("Failed to read podcast manifest for redirect", "error", err)
+		return ""
+	}
+	if date == "" {
+		date = manifest.Latest
+	}
+	for _, episode := range manifest.Episodes {
+		if episode.Date != date {
+			continue
 		}
-
-		// Write the audio data to buffer
-		_, err = io.Copy(&audioBuffer, resp.Body)
+		location, err := blobURL(ctx, episode.Key)
 		if err != nil {
-			return nil, fmt.Errorf("failed to write audio data: %w", err)
+			if !errors.Is(err, errNoBlobURL) {
+				logger.Warn("Failed to build podcast URL", "key", episode.Key, "error", err)
+			}
+			return ""
 		}
+		return location
 	}
-
-	return audioBuffer.Bytes(), nil
+	return ""
 }
 
-func getcachedpodcast(ctx context.Context, text string) ([]byte, error) {
-	key := "podcast-latest.mp3"
-	if r2Ready {
-		audioData, err := r2GetPodcast(ctx, key)
-		if err == nil {
-			logger.Info("Podcast found in R2", "key", key, "size", len(audioData))
-			return audioData, nil
-		} else {
-			logger.Warn("R2 Get failed for podcast, will generate", "key", key, "error", err)
+func getcachedpodcast(ctx context.Context, text string, date string) (*podcastAudio, error) {
+	archiveDate := date
+	if blobs != nil {
+		// The latest episode is whatever the manifest points at
+		if date == "" {
+			manifest, err := getManifest(ctx)
+			if err != nil {
+				logger.Warn("Failed to read podcast manifest", "error", err)
+			} else {
+				archiveDate = manifest.Latest
+			}
+		}
+
+		if archiveDate != "" {
+			key := podcastKey(archiveDate)
+			info, err := blobs.Stat(ctx, key)
+			if err == nil {
+				logger.Info("Podcast found in blob store", "key", key, "size", info.Size)
+				return &podcastAudio{
+					Content: &blobReader{ctx: ctx, store: blobs, key: key, size: info.Size},
+					Size:    info.Size,
+					ModTime: info.ModTime,
+					ETag:    info.ETag,
+				}, nil
+			}
+			logger.Warn("Blob store stat failed for podcast, will generate", "key", key, "error", err)
 		}
 	}
 
 	// Get conversation first
-	conversation, err := getcachedconversation(ctx, text)
+	conversation, _, err := getcachedconversation(ctx, text, date)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get conversation: %w", err)
 	}
 
 	// Generate audio podcast
-	audioData, err := generateaudiopodcast(ctx, conversation)
+	audioData, timings, err := generateaudiopodcast(ctx, conversation, date)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate audio podcast: %w", err)
 	}
 
-	// Upload to R2 if ready
-	if r2Ready {
-		err = r2PutPodcast(ctx, key, audioData)
+	// Archive in the blob store if configured
+	if blobs != nil {
+		if date == "" {
+			date = time.Now().UTC().Format(dateLayout)
+		}
+		err = archivePodcast(ctx, date, audioData, timings, paperURLsFromFeed([]byte(text)))
 		if err != nil {
-			logger.Warn("Failed to upload podcast to R2", "key", key, "error", err)
-		} else {
-			logger.Info("Successfully uploaded podcast to R2", "key", key, "size", len(audioData))
+			logger.Warn("Failed to archive podcast", "date", date, "error", err)
 		}
 	}
 
-	return audioData, nil
+	return generatedPodcastAudio(audioData), nil
 }
 
 // Handler handles all requests
@@ -1194,7 +5803,9 @@ func Handler(w http.ResponseWriter, r *http.Request) {
 	// Initialize Redis on first request (using background context for initialization)
 	initOnce.Do(func() {
 		initRedis()
-		initR2()
+		initBlobs()
+		initArchive()
+		initShow()
 	})
 
 	// Get the request context
@@ -1206,8 +5817,14 @@ func Handler(w http.ResponseWriter, r *http.Request) {
 		path = "/api" // Normalize empty path to /api
 	}
 
+	// Pick the output format from a path extension or ?format=
+	path, format := feedFormat(r, path)
+
 	// Get the full request URL for self-referential links
 	requestURL := "https://" + r.Host + r.URL.Path
+	if r.URL.RawQuery != "" {
+		requestURL += "?" + r.URL.RawQuery
+	}
 
 	// Apply CORS middleware
 	corsMiddleware(func(w http.ResponseWriter, r *http.Request) {
@@ -1217,7 +5834,7 @@ func Handler(w http.ResponseWriter, r *http.Request) {
 			w.Header().Set("Content-Type", "application/json")
 			healthStatus := map[string]interface{}{
 				"status":       "ok",
-				"endpoints":    []string{"/api/feed", "/api/summary", "/api/conversation", "/api/podcast"},
+				"endpoints":    []string{"/api/feed", "/api/feed/weekly", "/api/feed/monthly", "/api/summary", "/api/summary/weekly", "/api/summary/monthly", "/api/conversation", "/api/podcast", "/api/podcast/feed", "/api/podcast/transcript.vtt", "/api/podcast/chapters.json", "/api/archive"},
 				"cache_status": redisConnected,
 				"timestamp":    time.Now().UTC().Format(time.RFC3339),
 				"version":      "1.0.0",
@@ -1229,54 +5846,163 @@ func Handler(w http.ResponseWriter, r *http.Request) {
 			return
 
 		case "/api/feed":
+			date, err := parseDateParam(r)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			lang, err := parseLangParam(r, defaultFeedLang)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
 			// Pass request context to feed retrieval/generation
-			feed, err := getCachedFeed(reqCtx, requestURL)
+			var feed []byte
+			var cached cacheResult
+			view := "feed"
+			if r.URL.Query().Get("view") == "tldr" {
+				view = "tldr"
+				feed, cached, err = getCachedTLDRFeed(reqCtx, requestURL, date)
+			} else {
+				feed, cached, err = getCachedFeed(reqCtx, requestURL, date)
+			}
+			if err == nil {
+				feed, err = localizeFeed(feed, requestURL, lang, view)
+			}
 			if err != nil {
 				logger.Error("Failed to get cached feed", "error", err)
 				http.Error(w, "Error generating feed", http.StatusInternalServerError)
 				return
 			}
 
-			w.Header().Set("Content-Type", "application/rss+xml")
-			w.Write(feed)
+			setCacheHeaders(w, cached)
+			writeFeed(w, feed, format, requestURL)
 			return
 
 		case "/api/summary":
+			date, err := parseDateParam(r)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			lang, err := parseLangParam(r, defaultSummaryLang)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
 			// Pass request context to summary retrieval/generation
-			summary, err := getCachedSummary(reqCtx, requestURL)
+			summary, cached, err := getCachedSummary(reqCtx, requestURL, date, lang)
 			if err != nil {
 				logger.Error("Failed to get cached summary", "error", err)
 				http.Error(w, fmt.Sprintf("Error generating summary: %v", err), http.StatusInternalServerError)
 				return
 			}
 
-			w.Header().Set("Content-Type", "application/rss+xml")
-			w.Write(summary)
+			setCacheHeaders(w, cached)
+			writeFeed(w, summary, format, requestURL)
+			return
+
+		case "/api/feed/weekly", "/api/feed/monthly":
+			date, err := parseDateParam(r)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			lang, err := parseLangParam(r, defaultFeedLang)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			period := rollupPeriods[strings.TrimPrefix(path, "/api/feed/")]
+			feed, cached, err := getCachedRollupFeed(reqCtx, requestURL, period, date)
+			if err == nil {
+				feed, err = localizeFeed(feed, requestURL, lang, period.Name)
+			}
+			if err != nil {
+				logger.Error("Failed to get roll-up feed", "period", period.Name, "error", err)
+				http.Error(w, "Error generating feed", http.StatusInternalServerError)
+				return
+			}
+
+			setCacheHeaders(w, cached)
+			writeFeed(w, feed, format, requestURL)
+			return
+
+		case "/api/summary/weekly", "/api/summary/monthly":
+			date, err := parseDateParam(r)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			lang, err := parseLangParam(r, defaultSummaryLang)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			period := rollupPeriods[strings.TrimPrefix(path, "/api/summary/")]
+			summary, cached, err := getCachedRollupSummary(reqCtx, requestURL, period, date, lang)
+			if err != nil {
+				logger.Error("Failed to get roll-up summary", "period", period.Name, "error", err)
+				http.Error(w, fmt.Sprintf("Error generating summary: %v", err), http.StatusInternalServerError)
+				return
+			}
+
+			setCacheHeaders(w, cached)
+			writeFeed(w, summary, format, requestURL)
 			return
 
 		case "/api/conversation":
+			date, err := parseDateParam(r)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
 			// Pass request context to summary retrieval/generation
-			summary, err := getCachedFeed(reqCtx, requestURL)
+			summary, _, err := getCachedFeed(reqCtx, requestURL, date)
 			if err != nil {
 				logger.Error("Failed to get cached feed", "error", err)
 				http.Error(w, fmt.Sprintf("Error getting Feed: %v", err), http.StatusInternalServerError)
 				return
 			}
 			// Generate podcast conversation
-			conversation, err := getcachedconversation(reqCtx, string(summary))
+			conversation, cached, err := getcachedconversation(reqCtx, string(summary), date)
 			if err != nil {
 				logger.Error("Failed to generate podcast conversation", "error", err)
 				http.Error(w, fmt.Sprintf("Error generating podcast conversation: %v", err), http.StatusInternalServerError)
 				return
 			}
 			// Set content type to JSON
+			setCacheHeaders(w, cached)
 			w.Header().Set("Content-Type", "application/json")
 			// Write the conversation response
 			w.Write([]byte(conversation))
 			return
 
 		case "/api/podcast":
-			feed, err := getCachedFeed(reqCtx, requestURL)
+			date, err := parseDateParam(r)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			// Archived episodes can be downloaded from the blob store directly instead of
+			// streaming megabytes through the function
+			if podcastDirectDelivery() {
+				if location := podcastRedirectURL(reqCtx, date); location != "" {
+					w.Header().Set("Cache-Control", "no-store")
+					http.Redirect(w, r, location, http.StatusFound)
+					return
+				}
+			}
+
+			feed, _, err := getCachedFeed(reqCtx, requestURL, date)
 			if err != nil {
 				logger.Error("Failed to get cached feed", "error", err)
 				http.Error(w, fmt.Sprintf("Error getting Feed: %v", err), http.StatusInternalServerError)
@@ -1284,23 +6010,136 @@ func Handler(w http.ResponseWriter, r *http.Request) {
 			}
 
 			// Get or generate podcast audio
-			audioData, err := getcachedpodcast(reqCtx, string(feed))
+			audio, err := getcachedpodcast(reqCtx, string(feed), date)
 			if err != nil {
 				logger.Error("Failed to get/generate podcast", "error", err)
 				http.Error(w, fmt.Sprintf("Error with podcast: %v", err), http.StatusInternalServerError)
 				return
 			}
+			defer audio.Close()
+
+			servePodcastAudio(w, r, audio)
+			return
+
+		case "/api/podcast/feed":
+			var episodes []PodcastEpisode
+			if blobs != nil {
+				manifest, err := getManifest(reqCtx)
+				if err != nil {
+					logger.Error("Failed to read podcast manifest", "error", err)
+					http.Error(w, "Error listing podcast episodes", http.StatusInternalServerError)
+					return
+				}
+				episodes = manifest.Episodes
+			} else {
+				logger.Warn("No blob store configured, podcast feed will have no episodes")
+			}
+
+			feed, err := generatePodcastRSS(episodes, requestURL, "https://"+r.Host)
+			if err != nil {
+				logger.Error("Failed to generate podcast feed", "error", err)
+				http.Error(w, "Error generating podcast feed", http.StatusInternalServerError)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/rss+xml")
+			w.Write(feed)
+			return
+
+		case "/api/podcast/transcript":
+			date, err := parseDateParam(r)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			// Published episodes are transcribed from their own recorded lines, so the
+			// text always matches the audio even if the conversation cache has moved on
+			if blobs != nil {
+				timings, err := getEpisodeTimings(reqCtx, date)
+				if err == nil {
+					w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+					w.Write([]byte(timingsTranscript(timings)))
+					return
+				} else if !errors.Is(err, errNotArchived) {
+					logger.Warn("Failed to get episode timings for transcript", "date", date, "error", err)
+				}
+			}
+
+			feed, _, err := getCachedFeed(reqCtx, requestURL, date)
+			if err != nil {
+				logger.Error("Failed to get cached feed", "error", err)
+				http.Error(w, fmt.Sprintf("Error getting Feed: %v", err), http.StatusInternalServerError)
+				return
+			}
+			conversation, cached, err := getcachedconversation(reqCtx, string(feed), date)
+			if err != nil {
+				logger.Error("Failed to generate podcast conversation", "error", err)
+				http.Error(w, fmt.Sprintf("Error generating podcast conversation: %v", err), http.StatusInternalServerError)
+				return
+			}
+			transcript, err := formatTranscript(conversation)
+			if err != nil {
+				logger.Error("Failed to format transcript", "error", err)
+				http.Error(w, "Error formatting transcript", http.StatusInternalServerError)
+				return
+			}
+
+			setCacheHeaders(w, cached)
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			w.Write([]byte(transcript))
+			return
+
+		// feedFormat has already stripped the .json from /api/podcast/chapters.json
+		case "/api/podcast/transcript.vtt", "/api/podcast/transcript.srt", "/api/podcast/chapters":
+			date, err := parseDateParam(r)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			timings, err := getEpisodeTimings(reqCtx, date)
+			if errors.Is(err, errNotArchived) {
+				http.Error(w, "No timings recorded for this episode", http.StatusNotFound)
+				return
+			} else if err != nil {
+				logger.Error("Failed to get episode timings", "date", date, "error", err)
+				http.Error(w, "Error getting episode timings", http.StatusInternalServerError)
+				return
+			}
+
+			switch path {
+			case "/api/podcast/transcript.vtt":
+				w.Header().Set("Content-Type", "text/vtt; charset=utf-8")
+				w.Write([]byte(formatWebVTT(timings)))
+			case "/api/podcast/transcript.srt":
+				w.Header().Set("Content-Type", "application/x-subrip; charset=utf-8")
+				w.Write([]byte(formatSRT(timings)))
+			default:
+				w.Header().Set("Content-Type", "application/json+chapters")
+				json.NewEncoder(w).Encode(PodcastChapters{Version: "1.2.0", Chapters: podcastChapters(timings)})
+			}
+			return
 
-			// Set more specific headers for MP3 audio
-			w.Header().Set("Content-Type", "audio/mpeg")
-			w.Header().Set("Content-Disposition", "inline; filename=\"daily-papers-podcast.mp3\"")
-			w.Header().Set("Content-Length", fmt.Sprintf("%d", len(audioData)))
-			w.Header().Set("Accept-Ranges", "bytes")
+		case "/api/archive":
+			if archive == nil {
+				http.Error(w, "Archive not configured", http.StatusServiceUnavailable)
+				return
+			}
 
-			// Write the audio data directly to response
-			if _, err := w.Write(audioData); err != nil {
-				logger.Error("Failed to write audio response", "error", err)
+			dates := make(map[string][]string, len(archiveKinds))
+			for _, kind := range archiveKinds {
+				list, err := archive.List(reqCtx, kind)
+				if err != nil {
+					logger.Error("Failed to list archive", "kind", kind, "error", err)
+					http.Error(w, "Error listing archive", http.StatusInternalServerError)
+					return
+				}
+				dates[kind] = list
 			}
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(dates)
 			return
 
 		case "/api/update-cache":
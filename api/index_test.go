@@ -0,0 +1,454 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// MPEG Layer III headers without CRC
+const (
+	mpeg1Stereo128   = 0xFFFB9000 // MPEG-1, 128 kbps, 44100 Hz, stereo
+	mpeg1Stereo64    = 0xFFFB5000 // MPEG-1, 64 kbps, 44100 Hz, stereo
+	mpeg1Padded      = 0xFFFB9200 // MPEG-1, 128 kbps, 44100 Hz, stereo, padded
+	mpeg1Mono128     = 0xFFFB90C0 // MPEG-1, 128 kbps, 44100 Hz, mono
+	mpeg1Stereo48kHz = 0xFFFB9400 // MPEG-1, 128 kbps, 48000 Hz, stereo
+	mpeg2Mono64      = 0xFFF380C0 // MPEG-2, 64 kbps, 22050 Hz, mono
+	mpeg25Mono8      = 0xFFE310C0 // MPEG-2.5, 8 kbps, 11025 Hz, mono
+)
+
+// testFrame returns a whole frame for header, with fill as its payload.
+func testFrame(t *testing.T, header uint32, fill byte) []byte {
+	t.Helper()
+	f, ok := parseMP3Frame(binary.BigEndian.AppendUint32(nil, header))
+	if !ok {
+		t.Fatalf("invalid test header %08X", header)
+	}
+	frame := bytes.Repeat([]byte{fill}, f.size)
+	binary.BigEndian.PutUint32(frame, header)
+	return frame
+}
+
+func concat(parts ...[]byte) []byte {
+	return bytes.Join(parts, nil)
+}
+
+func TestParseMP3Frame(t *testing.T) {
+	tests := []struct {
+		name   string
+		header []byte
+		ok     bool
+		want   mp3Frame
+	}{
+		{
+			name:   "MPEG-1 stereo",
+			header: binary.BigEndian.AppendUint32(nil, mpeg1Stereo128),
+			ok:     true,
+			want:   mp3Frame{header: mpeg1Stereo128, mpeg1: true, size: 417, samples: 1152, sampleRate: 44100, bitrate: 128000},
+		},
+		{
+			name:   "MPEG-1 padded",
+			header: binary.BigEndian.AppendUint32(nil, mpeg1Padded),
+			ok:     true,
+			want:   mp3Frame{header: mpeg1Padded, mpeg1: true, size: 418, samples: 1152, sampleRate: 44100, bitrate: 128000},
+		},
+		{
+			name:   "MPEG-1 mono",
+			header: binary.BigEndian.AppendUint32(nil, mpeg1Mono128),
+			ok:     true,
+			want:   mp3Frame{header: mpeg1Mono128, mpeg1: true, mono: true, size: 417, samples: 1152, sampleRate: 44100, bitrate: 128000},
+		},
+		{
+			name:   "MPEG-2 mono",
+			header: binary.BigEndian.AppendUint32(nil, mpeg2Mono64),
+			ok:     true,
+			want:   mp3Frame{header: mpeg2Mono64, mono: true, size: 208, samples: 576, sampleRate: 22050, bitrate: 64000},
+		},
+		{
+			name:   "MPEG-2.5 mono",
+			header: binary.BigEndian.AppendUint32(nil, mpeg25Mono8),
+			ok:     true,
+			want:   mp3Frame{header: mpeg25Mono8, mono: true, size: 52, samples: 576, sampleRate: 11025, bitrate: 8000},
+		},
+		{name: "too short", header: []byte{0xFF, 0xFB, 0x90}},
+		{name: "no sync", header: binary.BigEndian.AppendUint32(nil, 0x7FFB9000)},
+		{name: "reserved version", header: binary.BigEndian.AppendUint32(nil, 0xFFEB9000)},
+		{name: "layer II", header: binary.BigEndian.AppendUint32(nil, 0xFFFD9000)},
+		{name: "reserved sample rate", header: binary.BigEndian.AppendUint32(nil, 0xFFFB9C00)},
+		{name: "free bitrate", header: binary.BigEndian.AppendUint32(nil, 0xFFFB0000)},
+		{name: "bad bitrate", header: binary.BigEndian.AppendUint32(nil, 0xFFFBF000)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseMP3Frame(tt.header)
+			if ok != tt.ok {
+				t.Fatalf("parseMP3Frame() ok = %v, want %v", ok, tt.ok)
+			}
+			if got != tt.want {
+				t.Errorf("parseMP3Frame() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMP3Frames(t *testing.T) {
+	a := testFrame(t, mpeg1Stereo128, 0x11)
+	b := testFrame(t, mpeg1Padded, 0x22)
+	c := testFrame(t, mpeg1Stereo64, 0x33)
+	id3 := id3Tag(podcastTag{Title: "Episode"})
+	id3v1 := append([]byte("TAG"), make([]byte, 125)...)
+
+	xing := testFrame(t, mpeg1Stereo128, 0)
+	copy(xing[4+32:], "Xing")
+	info := testFrame(t, mpeg1Stereo128, 0)
+	copy(info[4+32:], "Info")
+
+	tests := []struct {
+		name  string
+		input []byte
+		want  [][]byte
+	}{
+		{name: "empty", input: nil},
+		{name: "frames", input: concat(a, b, c), want: [][]byte{a, b, c}},
+		{name: "ID3 tags", input: concat(id3, a, b, id3v1), want: [][]byte{a, b}},
+		{name: "junk between frames", input: concat([]byte{0, 0xFF, 1}, a, []byte("junk"), b), want: [][]byte{a, b}},
+		{name: "Xing header", input: concat(xing, a, b), want: [][]byte{a, b}},
+		{name: "Info header", input: concat(info, c), want: [][]byte{c}},
+		{name: "Xing tag after first frame is audio", input: concat(a, xing), want: [][]byte{a, xing}},
+		{name: "truncated last frame", input: concat(a, b[:len(b)-1]), want: [][]byte{a}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frames, headers := mp3Frames(tt.input)
+			if len(frames) != len(tt.want) || len(headers) != len(tt.want) {
+				t.Fatalf("mp3Frames() returned %d frames and %d headers, want %d", len(frames), len(headers), len(tt.want))
+			}
+			for i := range frames {
+				if !bytes.Equal(frames[i], tt.want[i]) {
+					t.Errorf("frame %d differs", i)
+				}
+				if headers[i].size != len(frames[i]) {
+					t.Errorf("header %d size = %d, want %d", i, headers[i].size, len(frames[i]))
+				}
+			}
+		})
+	}
+}
+
+func TestXingFrame(t *testing.T) {
+	tests := []struct {
+		name   string
+		first  uint32
+		frames []uint32
+		cbr    bool
+		tag    string
+	}{
+		{name: "MPEG-1 CBR", first: mpeg1Stereo128, frames: []uint32{mpeg1Stereo128, mpeg1Stereo128, mpeg1Padded}, cbr: true, tag: "Info"},
+		{name: "MPEG-1 VBR", first: mpeg1Stereo128, frames: []uint32{mpeg1Stereo128, mpeg1Stereo64, mpeg1Stereo128, mpeg1Stereo64}, tag: "Xing"},
+		{name: "MPEG-1 mono", first: mpeg1Mono128, frames: []uint32{mpeg1Mono128, mpeg1Mono128}, cbr: true, tag: "Info"},
+		{name: "MPEG-2 mono", first: mpeg2Mono64, frames: []uint32{mpeg2Mono64, mpeg2Mono64, mpeg2Mono64}, cbr: true, tag: "Info"},
+		{name: "MPEG-2.5 mono", first: mpeg25Mono8, frames: []uint32{mpeg25Mono8}, cbr: true, tag: "Info"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var frames [][]byte
+			audioBytes := 0
+			for i, header := range tt.frames {
+				frame := testFrame(t, header, byte(i+1))
+				frames = append(frames, frame)
+				audioBytes += len(frame)
+			}
+			first, _ := parseMP3Frame(binary.BigEndian.AppendUint32(nil, tt.first))
+
+			xing := xingFrame(first, frames, audioBytes, tt.cbr)
+			f, ok := parseMP3Frame(xing)
+			if !ok {
+				t.Fatal("xingFrame() is not a valid frame")
+			}
+			if f.size != len(xing) {
+				t.Errorf("frame size = %d, header says %d", len(xing), f.size)
+			}
+			if f.format() != first.format() {
+				t.Errorf("format = %08X, want %08X", f.format(), first.format())
+			}
+			if !isVBRHeader(f, xing) {
+				t.Error("isVBRHeader() = false")
+			}
+
+			off := 4 + f.sideInfoSize()
+			if got := string(xing[off : off+4]); got != tt.tag {
+				t.Errorf("tag = %q, want %q", got, tt.tag)
+			}
+			if got := binary.BigEndian.Uint32(xing[off+4:]); got != xingHeaderFlags {
+				t.Errorf("flags = %#x, want %#x", got, xingHeaderFlags)
+			}
+			if got := binary.BigEndian.Uint32(xing[off+8:]); got != uint32(len(frames)) {
+				t.Errorf("frame count = %d, want %d", got, len(frames))
+			}
+			if got, want := binary.BigEndian.Uint32(xing[off+12:]), uint32(len(xing)+audioBytes); got != want {
+				t.Errorf("byte count = %d, want %d", got, want)
+			}
+			toc := xing[off+16 : off+116]
+			for i := 1; i < len(toc); i++ {
+				if toc[i] < toc[i-1] {
+					t.Fatalf("seek table decreases at %d: %v", i, toc)
+				}
+			}
+		})
+	}
+}
+
+func TestMuxMP3Formats(t *testing.T) {
+	stereo := concat(testFrame(t, mpeg1Stereo128, 1), testFrame(t, mpeg1Stereo64, 2))
+	tests := []struct {
+		name     string
+		segments [][]byte
+		err      error
+	}{
+		{name: "same format", segments: [][]byte{stereo, testFrame(t, mpeg1Padded, 3)}},
+		{name: "no frames", segments: [][]byte{stereo, []byte("not audio")}, err: errNoMP3Frames},
+		{name: "channel mode", segments: [][]byte{stereo, testFrame(t, mpeg1Mono128, 3)}, err: errMP3Format},
+		{name: "sample rate", segments: [][]byte{stereo, testFrame(t, mpeg1Stereo48kHz, 3)}, err: errMP3Format},
+		{name: "MPEG version", segments: [][]byte{testFrame(t, mpeg2Mono64, 1), testFrame(t, mpeg25Mono8, 2)}, err: errMP3Format},
+		{name: "within a segment", segments: [][]byte{concat(stereo, testFrame(t, mpeg1Mono128, 3))}, err: errMP3Format},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, duration, err := muxMP3(tt.segments, podcastTag{Title: "Episode"})
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("muxMP3() error = %v, want %v", err, tt.err)
+			}
+			if err != nil {
+				return
+			}
+			frames, _ := mp3Frames(out)
+			if len(frames) != 3 {
+				t.Errorf("muxed stream has %d audio frames, want 3", len(frames))
+			}
+			if want := 3 * (1152 * time.Second / 44100); duration != want {
+				t.Errorf("duration = %v, want %v", duration, want)
+			}
+		})
+	}
+}
+
+func TestAcceptFeedFormat(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   string
+	}{
+		{accept: "", want: formatRSS},
+		{accept: "*/*", want: formatRSS},
+		{accept: "application/json, text/plain, */*", want: formatRSS},
+		{accept: "application/rss+xml, application/atom+xml;q=0.9, */*;q=0.8", want: formatRSS},
+		{accept: "application/atom+xml, application/rss+xml", want: formatRSS},
+		{accept: "application/atom+xml", want: formatAtom},
+		{accept: "application/atom+xml, */*;q=0.1", want: formatAtom},
+		{accept: "application/rss+xml;q=0.5, application/atom+xml", want: formatAtom},
+		{accept: "application/feed+json", want: formatJSON},
+		{accept: "application/feed+json, application/json;q=0.9, */*;q=0.5", want: formatJSON},
+		{accept: "application/json", want: formatRSS},
+		{accept: "application/feed+json;q=0, */*", want: formatRSS},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			if got := acceptFeedFormat(tt.accept); got != tt.want {
+				t.Errorf("acceptFeedFormat(%q) = %q, want %q", tt.accept, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSummaryWords(t *testing.T) {
+	tests := []struct {
+		text string
+		want int
+	}{
+		{text: "", want: 0},
+		{text: "Agents learn to plan — and it works.", want: 7},
+		{text: "新しい論文です", want: 4},
+		{text: "今日のAI論文は3本です。", want: 6},
+		{text: "大規模言語モデルの推論を高速化する研究", want: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := summaryWords(tt.text); got != tt.want {
+				t.Errorf("summaryWords(%q) = %d, want %d", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSanitizeSummaryHTML(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "allowed tags", in: "<h2>Headline</h2><p>Text</p><ul><li>One</li></ul>", want: "<h2>Headline</h2><p>Text</p><ul><li>One</li></ul>"},
+		{name: "script removed", in: "<h2>A</h2><script>alert(1)</script><p>x</p>", want: "<h2>A</h2><p>x</p>"},
+		{name: "style and iframe removed", in: `<style>p{}</style><iframe src="https://example.com"></iframe><p>x</p>`, want: "<p>x</p>"},
+		{name: "javascript link unwrapped", in: `<p><a href="javascript:alert(1)">x</a></p>`, want: "<p>x</p>"},
+		{name: "protocol-relative and data links unwrapped", in: `<a href="//evil.example/x">P</a><a href="data:text/html,hi">Q</a>`, want: "PQ"},
+		{name: "http link kept without other attributes", in: `<a href="https://huggingface.co/papers/1" target="_blank" onclick="x">P</a>`, want: `<a href="https://huggingface.co/papers/1">P</a>`},
+		{name: "attributes dropped", in: `<p onclick="x" class="y">t</p>`, want: "<p>t</p>"},
+		{name: "unknown tags unwrapped", in: `<div><b>bold</b> <img src=x onerror=alert(1)></div>`, want: "bold"},
+		{name: "comments dropped", in: "<!-- note --><p>x</p>", want: "<p>x</p>"},
+		{name: "unbalanced markup closed", in: "<ul><li>one<li>two</ul><p>open", want: "<ul><li>one</li><li>two</li></ul><p>open</p>"},
+		{name: "code fence stripped", in: "```html\n<p>x</p>\n```", want: "<p>x</p>"},
+		{name: "text escaped", in: `<p>a < b & "c"</p>`, want: "<p>a &lt; b &amp; &#34;c&#34;</p>"},
+		{name: "CDATA terminator in text", in: "<p>]]></p>", want: "<p>]]&gt;</p>"},
+		{name: "CDATA terminator in link", in: `<a href="https://example.com/]]>">t</a>`, want: `<a href="https://example.com/%5D%5D%3E">t</a>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sanitizeSummaryHTML(tt.in)
+			if got != tt.want {
+				t.Errorf("sanitizeSummaryHTML(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+			if strings.Contains(got, "]]>") {
+				t.Errorf("sanitizeSummaryHTML(%q) contains a CDATA terminator", tt.in)
+			}
+		})
+	}
+}
+
+func TestVerifyCitations(t *testing.T) {
+	papers := []Paper{
+		{Title: "Fast Agents", URL: "https://huggingface.co/papers/2401.00001", ArxivID: "2401.00001"},
+		{Title: "Slow Models", URL: "https://huggingface.co/papers/2401.00002", ArxivID: "2401.00002"},
+		{Title: "Quiet Data", URL: "https://huggingface.co/papers/2401.00003", ArxivID: "2401.00003"},
+	}
+
+	tests := []struct {
+		name    string
+		summary string
+		want    string
+		report  CitationReport
+	}{
+		{
+			name:    "paper links kept",
+			summary: `<p><a href="http://huggingface.co/papers/2401.00001/">Fast Agents</a> and <a href="https://huggingface.co/papers/2401.00002">Slow Models</a></p>`,
+			want:    `<p><a href="http://huggingface.co/papers/2401.00001/">Fast Agents</a> and <a href="https://huggingface.co/papers/2401.00002">Slow Models</a></p>`,
+			report:  CitationReport{Papers: 3, Cited: 2, Uncited: []string{"Quiet Data"}},
+		},
+		{
+			name:    "arXiv link rewritten",
+			summary: `<p><a href="https://arxiv.org/abs/2401.00002v2">this paper</a></p>`,
+			want:    `<p><a href="https://huggingface.co/papers/2401.00002">this paper</a></p>`,
+			report:  CitationReport{Papers: 3, Cited: 1, Rewritten: 1, Uncited: []string{"Fast Agents", "Quiet Data"}},
+		},
+		{
+			name:    "title match rewritten",
+			summary: `<p><a href="https://example.com/made-up">Fast  agents</a></p>`,
+			want:    `<p><a href="https://huggingface.co/papers/2401.00001">Fast  agents</a></p>`,
+			report:  CitationReport{Papers: 3, Cited: 1, Rewritten: 1, Uncited: []string{"Slow Models", "Quiet Data"}},
+		},
+		{
+			name:    "outside links unwrapped",
+			summary: `<a href="https://example.com/">Top</a><p>See <a href="https://example.com/other">a <b>blog</b></a>.</p>`,
+			want:    `Top<p>See a blog.</p>`,
+			report:  CitationReport{Papers: 3, Removed: 2, Uncited: []string{"Fast Agents", "Slow Models", "Quiet Data"}},
+		},
+		{
+			name: "all papers cited once each",
+			summary: `<p><a href="https://huggingface.co/papers/2401.00001">A</a> <a href="https://huggingface.co/papers/2401.00001">A again</a> ` +
+				`<a href="https://huggingface.co/papers/2401.00002">B</a> <a href="https://arxiv.org/pdf/2401.00003">C</a></p>`,
+			want: `<p><a href="https://huggingface.co/papers/2401.00001">A</a> <a href="https://huggingface.co/papers/2401.00001">A again</a> ` +
+				`<a href="https://huggingface.co/papers/2401.00002">B</a> <a href="https://huggingface.co/papers/2401.00003">C</a></p>`,
+			report: CitationReport{Papers: 3, Cited: 3, Rewritten: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, report := verifyCitations(tt.summary, papers)
+			if got != tt.want {
+				t.Errorf("verifyCitations() summary = %q, want %q", got, tt.want)
+			}
+			if report.Papers != tt.report.Papers || report.Cited != tt.report.Cited || report.Rewritten != tt.report.Rewritten || report.Removed != tt.report.Removed {
+				t.Errorf("verifyCitations() report = %+v, want %+v", report, tt.report)
+			}
+			if strings.Join(report.Uncited, "|") != strings.Join(tt.report.Uncited, "|") {
+				t.Errorf("verifyCitations() uncited = %q, want %q", report.Uncited, tt.report.Uncited)
+			}
+			if want := float64(tt.report.Cited) / float64(tt.report.Papers); report.Coverage() != want {
+				t.Errorf("Coverage() = %v, want %v", report.Coverage(), want)
+			}
+		})
+	}
+
+	if got := (CitationReport{}).Coverage(); got != 0 {
+		t.Errorf("Coverage() without papers = %v, want 0", got)
+	}
+}
+
+func TestServePodcastAudio(t *testing.T) {
+	data := []byte("0123456789abcdefghij")
+	etag := generatedPodcastAudio(data).ETag
+
+	tests := []struct {
+		name         string
+		header       map[string]string
+		status       int
+		contentRange string
+		body         string
+	}{
+		{name: "whole file", status: http.StatusOK, body: string(data)},
+		{name: "single range", header: map[string]string{"Range": "bytes=5-9"}, status: http.StatusPartialContent, contentRange: "bytes 5-9/20", body: "56789"},
+		{name: "suffix range", header: map[string]string{"Range": "bytes=-3"}, status: http.StatusPartialContent, contentRange: "bytes 17-19/20", body: "hij"},
+		{name: "multiple ranges", header: map[string]string{"Range": "bytes=0-1,5-6"}, status: http.StatusRequestedRangeNotSatisfiable, contentRange: "bytes */20"},
+		{name: "range past the end", header: map[string]string{"Range": "bytes=30-40"}, status: http.StatusRequestedRangeNotSatisfiable, contentRange: "bytes */20"},
+		{name: "matching ETag", header: map[string]string{"If-None-Match": etag}, status: http.StatusNotModified},
+		{name: "stale ETag", header: map[string]string{"If-None-Match": `"stale"`}, status: http.StatusOK, body: string(data)},
+		{name: "If-Range with stale ETag", header: map[string]string{"Range": "bytes=5-9", "If-Range": `"stale"`}, status: http.StatusOK, body: string(data)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/api/podcast/audio.mp3", nil)
+			for k, v := range tt.header {
+				r.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+			servePodcastAudio(w, r, generatedPodcastAudio(data))
+
+			if w.Code != tt.status {
+				t.Fatalf("status = %d, want %d", w.Code, tt.status)
+			}
+			if got := w.Header().Get("Content-Range"); got != tt.contentRange {
+				t.Errorf("Content-Range = %q, want %q", got, tt.contentRange)
+			}
+			if got := w.Header().Get("ETag"); tt.status < http.StatusBadRequest && got != etag {
+				t.Errorf("ETag = %q, want %q", got, etag)
+			}
+			if tt.body != "" && w.Body.String() != tt.body {
+				t.Errorf("body = %q, want %q", w.Body.String(), tt.body)
+			}
+		})
+	}
+}
+
+func TestFormatSRT(t *testing.T) {
+	timings := []SegmentTiming{
+		{Speaker: "Host", Text: " Welcome to <b>today's</b> papers. ", Start: 0, End: 2.5},
+		{Speaker: "Guest", Text: "First line.\n\nSecond line.", Start: 2.5, End: 3601.234},
+	}
+	want := "1\n00:00:00,000 --> 00:00:02,500\nHost: Welcome to <b>today's</b> papers.\n\n" +
+		"2\n00:00:02,500 --> 01:00:01,234\nGuest: First line. Second line.\n\n"
+	if got := formatSRT(timings); got != want {
+		t.Errorf("formatSRT() = %q, want %q", got, want)
+	}
+}